@@ -118,6 +118,7 @@ func mainMetrics() {
 	// Register the views
 	if err := view.Register(
 		client.LatencyView,
+		client.AttemptsView,
 		//transporthttp.LatencyView, // TODO: add back http metrics.
 		event.EventMarshalLatencyView,
 		json.LatencyView,
@@ -0,0 +1,81 @@
+package binding
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+const (
+	FORMAT_EVENT_BATCH = "FORMAT_EVENT_BATCH"
+)
+
+// BatchReader allows to perform an optimized encoding of a batch Message to a specific data structure.
+// A Message with EncodingBatch encoding *must* implement BatchReader.
+type BatchReader interface {
+	// ReadBatch transfers a batch-mode message to a BatchWriter.
+	// It must return ErrNotBatch if message is not in batch mode.
+	//
+	// Returns a different err if something wrong happened while trying to read the batch.
+	// In this case, the caller must Finish the message with appropriate error.
+	ReadBatch(context.Context, BatchWriter) error
+}
+
+// BatchMessage is the interface to a binding-specific message containing several events.
+// Use ToEvents to read all the events contained in a BatchMessage.
+type BatchMessage interface {
+	Message
+	BatchReader
+}
+
+// EventBatchMessage type-converts a slice of event.Event objects to implement BatchMessage.
+// This allows local event.Event objects to be sent in a single batch via Sender.Send()
+//     s.Send(ctx, binding.EventBatchMessage(events))
+type EventBatchMessage []event.Event
+
+func (m EventBatchMessage) ReadEncoding() Encoding {
+	return EncodingBatch
+}
+
+func (m EventBatchMessage) ReadStructured(context.Context, StructuredWriter) error {
+	return ErrNotStructured
+}
+
+func (m EventBatchMessage) ReadBinary(context.Context, BinaryWriter) error {
+	return ErrNotBinary
+}
+
+func (m EventBatchMessage) ReadBatch(ctx context.Context, writer BatchWriter) error {
+	f := GetOrDefaultFromCtx(ctx, FORMAT_EVENT_BATCH, format.JSONBatch).(format.BatchFormat)
+	b, err := f.MarshalBatch(m)
+	if err != nil {
+		return err
+	}
+	return writer.SetBatchEvents(ctx, f, bytes.NewReader(b))
+}
+
+func (EventBatchMessage) Finish(error) error { return nil }
+
+var _ BatchMessage = (EventBatchMessage)(nil) // Test it conforms to the interface
+
+// Configure which format to use when marshalling a slice of events to batch mode
+func UseFormatForBatch(ctx context.Context, f format.BatchFormat) context.Context {
+	return context.WithValue(ctx, FORMAT_EVENT_BATCH, f)
+}
+
+// batchReaderOf walks through the MessageWrapper chain looking for a BatchReader
+func batchReaderOf(message MessageReader) BatchReader {
+	for m := message; m != nil; {
+		if br, ok := m.(BatchReader); ok {
+			return br
+		}
+		if mw, ok := m.(MessageWrapper); ok {
+			m = mw.GetWrappedMessage()
+		} else {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package binding_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/binding/transformer"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+type mockBatchMessage struct {
+	test.MockStructuredMessage
+}
+
+func (m *mockBatchMessage) ReadEncoding() binding.Encoding {
+	return binding.EncodingBatch
+}
+
+func (m *mockBatchMessage) ReadStructured(context.Context, binding.StructuredWriter) error {
+	return binding.ErrNotStructured
+}
+
+func (m *mockBatchMessage) ReadBatch(ctx context.Context, writer binding.BatchWriter) error {
+	return writer.SetBatchEvents(ctx, format.JSONBatch, bytes.NewReader(m.Bytes))
+}
+
+func (m *mockBatchMessage) SetBatchEvents(ctx context.Context, f format.BatchFormat, events io.Reader) error {
+	return m.SetStructuredEvent(ctx, f, events)
+}
+
+func mustCreateMockBatchMessage(t *testing.T, events []event.Event) *mockBatchMessage {
+	b, err := format.JSONBatch.MarshalBatch(events)
+	require.NoError(t, err)
+	return &mockBatchMessage{test.MockStructuredMessage{Format: format.JSONBatch, Bytes: b}}
+}
+
+func TestToEventsBatch(t *testing.T) {
+	events := test.Events()
+	messages := []binding.Message{
+		binding.EventBatchMessage(events),
+		mustCreateMockBatchMessage(t, events),
+		binding.WithFinish(mustCreateMockBatchMessage(t, events), nil),
+	}
+	test.EachMessage(t, messages, func(t *testing.T, m binding.Message) {
+		got, err := binding.ToEvents(context.Background(), m, nil)
+		require.NoError(t, err)
+		require.Len(t, got, len(events))
+		for i := range events {
+			test.AssertEventEquals(t, test.ExToStr(t, events[i]), test.ExToStr(t, got[i]))
+		}
+
+		_, err = binding.ToEvent(context.Background(), m, nil)
+		require.Equal(t, binding.ErrCannotConvertBatchToEvent, err)
+	})
+}
+
+func TestToEventsSingleEvent(t *testing.T) {
+	e := test.FullEvent()
+	got, err := binding.ToEvents(context.Background(), test.MustCreateMockBinaryMessage(e), nil)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	test.AssertEventEquals(t, e, got[0])
+}
+
+func TestToEventsTransformers(t *testing.T) {
+	events := []event.Event{test.MinEvent(), test.MinEvent()}
+	got, err := binding.ToEvents(context.Background(), mustCreateMockBatchMessage(t, events), binding.TransformerFactories{
+		transformer.AddExtension("ext", "aaa"),
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	for _, e := range got {
+		require.Equal(t, "aaa", e.Extensions()["ext"])
+	}
+}
+
+func TestWriteBatch(t *testing.T) {
+	events := []event.Event{test.FullEvent(), test.MinEvent()}
+
+	out := &mockBatchMessage{}
+	enc, err := binding.Write(context.Background(), binding.EventBatchMessage(events), out, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, binding.EncodingBatch, enc)
+	require.Equal(t, format.JSONBatch, out.Format)
+
+	got, err := format.JSONBatch.UnmarshalBatch(out.Bytes)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	test.AssertEventEquals(t, test.ExToStr(t, events[0]), test.ExToStr(t, got[0]))
+	test.AssertEventEquals(t, events[1], got[1])
+
+	// Without BatchWriter support, the batch cannot be written
+	_, err = binding.Write(context.Background(), binding.EventBatchMessage(events), &test.MockStructuredMessage{}, &test.MockBinaryMessage{}, nil)
+	require.Equal(t, binding.ErrCannotConvertBatchToEvent, err)
+}
+
+func TestWriteBatchSingleEvent(t *testing.T) {
+	e := test.MinEvent()
+
+	out := &mockBatchMessage{}
+	require.NoError(t, binding.WriteBatch(context.Background(), binding.EventMessage(e), out, nil))
+
+	got, err := format.JSONBatch.UnmarshalBatch(out.Bytes)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	test.AssertEventEquals(t, e, got[0])
+}
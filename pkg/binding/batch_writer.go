@@ -0,0 +1,19 @@
+package binding
+
+import (
+	"context"
+	"io"
+
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+)
+
+// BatchWriter is used to visit a batch Message and generate a new representation.
+//
+// Protocols that supports batch encoding should implement this interface to implement direct
+// batch to batch encoding and events to batch encoding.
+// When the StructuredWriter of a protocol implements BatchWriter too, binding.Write uses it
+// to write batch messages.
+type BatchWriter interface {
+	// SetBatchEvents receives an io.Reader for the whole batch of events.
+	SetBatchEvents(ctx context.Context, format format.BatchFormat, events io.Reader) error
+}
@@ -34,6 +34,13 @@ func CopyMessage(ctx context.Context, m binding.Message, transformers binding.Tr
 		}
 		return binding.EventMessage(*e), nil
 	}
+	if originalMessageEncoding == binding.EncodingBatch {
+		events, err := binding.ToEvents(ctx, m, transformers)
+		if err != nil {
+			return nil, err
+		}
+		return binding.EventBatchMessage(events), nil
+	}
 
 	sm := structBufferedMessage{}
 	bm := binaryBufferedMessage{}
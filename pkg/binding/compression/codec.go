@@ -0,0 +1,166 @@
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec compresses and decompresses the data.
+type Codec interface {
+	// Name returns the name of the encoding, used in the dataencoding extension
+	// and in the Content-Encoding and Accept-Encoding HTTP headers.
+	Name() string
+	// NewWriter returns a writer compressing the data written to it into w.
+	// The data is completely written to w only after closing the writer.
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+	// NewReader returns a reader decompressing the data read from r.
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+var (
+	// Gzip compresses the data in the gzip format.
+	Gzip Codec = gzipCodec{}
+	// Zstd compresses the data in the Zstandard format.
+	Zstd Codec = zstdCodec{}
+	// Snappy compresses the data in the snappy framing format.
+	Snappy Codec = snappyCodec{}
+)
+
+// DefaultMaxSize is the default maximum size of the decompressed data, 32 MiB
+const DefaultMaxSize = 32 << 20
+
+// ErrTooLarge is returned when the decompressed data is larger than the maximum size
+var ErrTooLarge = errors.New("decompressed data too large")
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{
+		Gzip.Name():   Gzip,
+		Zstd.Name():   Zstd,
+		Snappy.Name(): Snappy,
+	}
+)
+
+// Register adds a codec, or replaces the codec with the same name.
+func Register(codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[strings.ToLower(codec.Name())] = codec
+}
+
+// Lookup returns the codec registered with name, ignoring the case, or nil.
+func Lookup(name string) Codec {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	return codecs[strings.ToLower(name)]
+}
+
+// Names returns the sorted names of the registered codecs.
+func Names() []string {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	names := make([]string, 0, len(codecs))
+	for name := range codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Compress returns data compressed with codec.
+func Compress(codec Codec, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := codec.NewWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress returns data decompressed with codec.
+// It fails with ErrTooLarge if the decompressed data is larger than maxSize bytes.
+func Decompress(codec Codec, data []byte, maxSize int64) ([]byte, error) {
+	r, err := codec.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(LimitReader(r, maxSize))
+}
+
+// LimitReader returns a reader reading from r, failing with ErrTooLarge after maxSize bytes.
+// Unlike io.LimitReader, the data beyond maxSize is an error and it is not silently dropped.
+func LimitReader(r io.Reader, maxSize int64) io.Reader {
+	return &limitedReader{r: io.LimitReader(r, maxSize+1), max: maxSize}
+}
+
+type limitedReader struct {
+	r    io.Reader
+	max  int64
+	read int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.max {
+		return n - int(l.read-l.max), ErrTooLarge
+	}
+	return n, err
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) Name() string { return "gzip" }
+
+func (gzipCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+func (gzipCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+type zstdCodec struct{}
+
+func (zstdCodec) Name() string { return "zstd" }
+
+func (zstdCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+}
+
+func (zstdCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return d.IOReadCloser(), nil
+}
+
+type snappyCodec struct{}
+
+func (snappyCodec) Name() string { return "snappy" }
+
+func (snappyCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return snappy.NewBufferedWriter(w), nil
+}
+
+func (snappyCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return ioutil.NopCloser(snappy.NewReader(r)), nil
+}
@@ -0,0 +1,195 @@
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/types"
+)
+
+// EncodingExtension holds the name of the codec which compressed the data
+const EncodingExtension = "dataencoding"
+
+// ErrUnsupportedEncoding is returned when the data is compressed with an unregistered codec
+var ErrUnsupportedEncoding = errors.New("unsupported data encoding")
+
+// Compressing returns a transformer which compresses the data of the events with codec.
+// Events without data, or already compressed, are left unchanged.
+func Compressing(codec Codec) binding.TransformerFactory {
+	return compressTransformerFactory{codec: codec}
+}
+
+// Decompressing returns a transformer which decompresses the data of the events compressed with
+// Compressing, using the registered codecs, and removes the dataencoding extension.
+// Events without the dataencoding extension are left unchanged.
+// Data decompressed to more than maxSize bytes fails with ErrTooLarge, see DefaultMaxSize.
+func Decompressing(maxSize int64) binding.TransformerFactory {
+	return decompressTransformerFactory{maxSize: maxSize}
+}
+
+func lookup(encoding interface{}) (Codec, error) {
+	name, err := types.ToString(encoding)
+	if err != nil {
+		return nil, err
+	}
+	codec := Lookup(name)
+	if codec == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedEncoding, name)
+	}
+	return codec, nil
+}
+
+type compressTransformerFactory struct {
+	codec Codec
+}
+
+func (f compressTransformerFactory) StructuredTransformer(binding.StructuredWriter) binding.StructuredWriter {
+	return nil
+}
+
+func (f compressTransformerFactory) BinaryTransformer(encoder binding.BinaryWriter) binding.BinaryWriter {
+	return &compressTransformer{BinaryWriter: encoder, codec: f.codec}
+}
+
+func (f compressTransformerFactory) EventTransformer() binding.EventTransformer {
+	return func(e *event.Event) error {
+		if _, ok := e.Extensions()[EncodingExtension]; ok {
+			return nil
+		}
+		data, err := e.DataBytes()
+		if err != nil || len(data) == 0 {
+			return err
+		}
+		compressed, err := Compress(f.codec, data)
+		if err != nil {
+			return err
+		}
+		if err := e.Context.SetExtension(EncodingExtension, f.codec.Name()); err != nil {
+			return err
+		}
+		return e.SetData(compressed)
+	}
+}
+
+// compressTransformer holds the data until End, to add the dataencoding extension before it.
+type compressTransformer struct {
+	binding.BinaryWriter
+	codec      Codec
+	compressed bool
+	data       []byte
+}
+
+func (b *compressTransformer) SetExtension(name string, value interface{}) error {
+	if name == EncodingExtension {
+		b.compressed = true
+	}
+	return b.BinaryWriter.SetExtension(name, value)
+}
+
+func (b *compressTransformer) SetData(data io.Reader) (err error) {
+	b.data, err = ioutil.ReadAll(data)
+	return err
+}
+
+func (b *compressTransformer) End() error {
+	if len(b.data) > 0 {
+		data := b.data
+		if !b.compressed {
+			compressed, err := Compress(b.codec, data)
+			if err != nil {
+				return err
+			}
+			if err := b.BinaryWriter.SetExtension(EncodingExtension, b.codec.Name()); err != nil {
+				return err
+			}
+			data = compressed
+		}
+		if err := b.BinaryWriter.SetData(bytes.NewReader(data)); err != nil {
+			return err
+		}
+	}
+	return b.BinaryWriter.End()
+}
+
+type decompressTransformerFactory struct {
+	maxSize int64
+}
+
+func (f decompressTransformerFactory) StructuredTransformer(binding.StructuredWriter) binding.StructuredWriter {
+	return nil
+}
+
+func (f decompressTransformerFactory) BinaryTransformer(encoder binding.BinaryWriter) binding.BinaryWriter {
+	return &decompressTransformer{BinaryWriter: encoder, maxSize: f.maxSize}
+}
+
+func (f decompressTransformerFactory) EventTransformer() binding.EventTransformer {
+	return func(e *event.Event) error {
+		encoding, ok := e.Extensions()[EncodingExtension]
+		if !ok {
+			return nil
+		}
+		codec, err := lookup(encoding)
+		if err != nil {
+			return err
+		}
+		compressed, err := e.DataBytes()
+		if err != nil {
+			return err
+		}
+		data, err := Decompress(codec, compressed, f.maxSize)
+		if err != nil {
+			return err
+		}
+		if err := e.Context.SetExtension(EncodingExtension, nil); err != nil {
+			return err
+		}
+		return e.SetData(data)
+	}
+}
+
+// decompressTransformer holds the data until End, because the dataencoding extension
+// can be written after it.
+type decompressTransformer struct {
+	binding.BinaryWriter
+	maxSize  int64
+	encoding interface{}
+	data     []byte
+}
+
+func (b *decompressTransformer) SetExtension(name string, value interface{}) error {
+	if name == EncodingExtension {
+		b.encoding = value
+		return nil
+	}
+	return b.BinaryWriter.SetExtension(name, value)
+}
+
+func (b *decompressTransformer) SetData(data io.Reader) (err error) {
+	b.data, err = ioutil.ReadAll(data)
+	return err
+}
+
+func (b *decompressTransformer) End() error {
+	data := b.data
+	if b.encoding != nil && len(data) > 0 {
+		codec, err := lookup(b.encoding)
+		if err != nil {
+			return err
+		}
+		if data, err = Decompress(codec, data, b.maxSize); err != nil {
+			return err
+		}
+	}
+	if len(data) > 0 {
+		if err := b.BinaryWriter.SetData(bytes.NewReader(data)); err != nil {
+			return err
+		}
+	}
+	return b.BinaryWriter.End()
+}
@@ -0,0 +1,144 @@
+package compression_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/compression"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+var codecs = []compression.Codec{compression.Gzip, compression.Zstd, compression.Snappy}
+
+// transform writes m in mock messages with the transformers, and converts the result to an event.
+func transform(t *testing.T, m binding.Message, transformers ...binding.TransformerFactory) (event.Event, error) {
+	ctx := context.Background()
+	mockStructured := test.MockStructuredMessage{}
+	mockBinary := test.MockBinaryMessage{}
+	enc, err := binding.Write(ctx, m, &mockStructured, &mockBinary, transformers)
+	if err != nil {
+		return event.Event{}, err
+	}
+	var e *event.Event
+	if enc == binding.EncodingStructured {
+		e, err = binding.ToEvent(ctx, &mockStructured, nil)
+	} else {
+		e, err = binding.ToEvent(ctx, &mockBinary, nil)
+	}
+	require.NoError(t, err)
+	return *e, nil
+}
+
+func TestCompressDecompress(t *testing.T) {
+	data := bytes.Repeat([]byte("compressible "), 100)
+	for _, codec := range codecs {
+		t.Run(codec.Name(), func(t *testing.T) {
+			compressed, err := compression.Compress(codec, data)
+			require.NoError(t, err)
+			require.Less(t, len(compressed), len(data))
+			decompressed, err := compression.Decompress(codec, compressed, compression.DefaultMaxSize)
+			require.NoError(t, err)
+			require.Equal(t, data, decompressed)
+			decompressed, err = compression.Decompress(codec, compressed, int64(len(data)))
+			require.NoError(t, err)
+			require.Equal(t, data, decompressed)
+
+			_, err = compression.Decompress(codec, data, compression.DefaultMaxSize)
+			require.Error(t, err)
+
+			// The data decompressed beyond the maximum size is an error
+			_, err = compression.Decompress(codec, compressed, int64(len(data)-1))
+			require.True(t, errors.Is(err, compression.ErrTooLarge))
+		})
+	}
+}
+
+func TestDecompressingTooLarge(t *testing.T) {
+	e := test.FullEvent()
+	require.NoError(t, e.SetData(bytes.Repeat([]byte("compressible "), 100)))
+	compressed, err := transform(t, binding.EventMessage(e), compression.Compressing(compression.Gzip))
+	require.NoError(t, err)
+
+	_, err = transform(t, binding.EventMessage(test.CopyEventContext(compressed)), compression.Decompressing(10))
+	require.True(t, errors.Is(err, compression.ErrTooLarge))
+	_, err = transform(t, test.MustCreateMockBinaryMessage(compressed), compression.Decompressing(10))
+	require.True(t, errors.Is(err, compression.ErrTooLarge))
+}
+
+func TestCompressingDecompressing(t *testing.T) {
+	e := test.FullEvent()
+	require.NoError(t, e.SetData(bytes.Repeat([]byte("compressible "), 100)))
+	data := e.Data.([]byte)
+
+	messages := map[string]func(event.Event) binding.Message{
+		"binary":     test.MustCreateMockBinaryMessage,
+		"structured": test.MustCreateMockStructuredMessage,
+		"event":      func(e event.Event) binding.Message { return binding.EventMessage(e) },
+	}
+	for _, codec := range codecs {
+		for name, toMessage := range messages {
+			t.Run(codec.Name()+"/"+name, func(t *testing.T) {
+				compressed, err := transform(t, toMessage(test.CopyEventContext(e)), compression.Compressing(codec))
+				require.NoError(t, err)
+				require.Equal(t, codec.Name(), compressed.Extensions()[compression.EncodingExtension])
+				require.Less(t, len(compressed.Data.([]byte)), len(data))
+
+				// Compressing twice doesn't change the event
+				again, err := transform(t, binding.EventMessage(test.CopyEventContext(compressed)), compression.Compressing(compression.Gzip))
+				require.NoError(t, err)
+				test.AssertEventEquals(t, compressed, again)
+
+				for name, toMessage := range messages {
+					t.Run(name, func(t *testing.T) {
+						decompressed, err := transform(t, toMessage(test.CopyEventContext(compressed)), compression.Decompressing(compression.DefaultMaxSize))
+						require.NoError(t, err)
+						require.NotContains(t, decompressed.Extensions(), compression.EncodingExtension)
+						require.Equal(t, e.ID(), decompressed.ID())
+						require.Equal(t, data, decompressed.Data)
+					})
+				}
+			})
+		}
+	}
+}
+
+func TestWithoutData(t *testing.T) {
+	e := test.MinEvent()
+	have, err := transform(t, binding.EventMessage(test.CopyEventContext(e)), compression.Compressing(compression.Gzip))
+	require.NoError(t, err)
+	test.AssertEventEquals(t, e, have)
+
+	have, err = transform(t, test.MustCreateMockBinaryMessage(e), compression.Decompressing(compression.DefaultMaxSize))
+	require.NoError(t, err)
+	test.AssertEventEquals(t, e, have)
+}
+
+func TestUnsupportedEncoding(t *testing.T) {
+	e := test.FullEvent()
+	e.SetExtension(compression.EncodingExtension, "unknown")
+	_, err := transform(t, binding.EventMessage(test.CopyEventContext(e)), compression.Decompressing(compression.DefaultMaxSize))
+	require.True(t, errors.Is(err, compression.ErrUnsupportedEncoding))
+	_, err = transform(t, test.MustCreateMockBinaryMessage(e), compression.Decompressing(compression.DefaultMaxSize))
+	require.True(t, errors.Is(err, compression.ErrUnsupportedEncoding))
+}
+
+type customCodec struct{ compression.Codec }
+
+func (customCodec) Name() string { return "X-Custom" }
+
+func TestRegister(t *testing.T) {
+	// The registry is global, the codecs registered by previous runs are still there
+	require.Subset(t, compression.Names(), []string{"gzip", "snappy", "zstd"})
+	require.Equal(t, compression.Gzip, compression.Lookup("GZIP"))
+	require.Nil(t, compression.Lookup("x-unknown"))
+
+	compression.Register(customCodec{compression.Gzip})
+	require.Equal(t, "X-Custom", compression.Lookup("x-custom").Name())
+	require.Contains(t, compression.Names(), "x-custom")
+}
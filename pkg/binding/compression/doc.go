@@ -0,0 +1,17 @@
+/*
+Package compression compresses the data of the events with gzip, zstd or snappy.
+
+The transformers returned by Compressing compress the data and store the name of the codec in the
+extension dataencoding, the transformers returned by Decompressing decompress it:
+
+	sender, err := kafka_sarama.NewSender(client, topic, kafka_sarama.WithTransformer(compression.Compressing(compression.Zstd)))
+
+	event, err := binding.ToEvent(ctx, message, binding.TransformerFactories{compression.Decompressing(compression.DefaultMaxSize)})
+
+The size of the decompressed data is limited, to protect the receivers from small payloads
+decompressing to huge amounts of data: larger data fails with ErrTooLarge.
+
+The HTTP transport negotiates the compression with the Content-Encoding and Accept-Encoding headers
+instead, using the codecs registered in this package.
+*/
+package compression
@@ -0,0 +1,68 @@
+package dataref
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/extensions"
+)
+
+// Offload returns a transformer which moves the data of the events bigger than threshold bytes
+// to the store, replacing it with the dataref extension.
+// The events which already have a dataref extension are left unchanged.
+// The transformers have no context of their own, so ctx is passed to the store.
+func Offload(ctx context.Context, store BlobStore, threshold int) binding.TransformerFactory {
+	return binding.EventTransformerFactory(func(e *event.Event) error {
+		if _, ok := extensions.GetDataRef(*e); ok {
+			return nil
+		}
+		data, err := e.DataBytes()
+		if err != nil {
+			return err
+		}
+		if len(data) <= threshold {
+			return nil
+		}
+		ref, err := store.Put(ctx, data)
+		if err != nil {
+			return err
+		}
+		if err := extensions.SetDataRef(e, ref); err != nil {
+			return err
+		}
+		e.Data = nil
+		e.DataEncoded = false
+		return nil
+	})
+}
+
+// Resolve returns a transformer which reads from the store the data of the events
+// without data and with the dataref extension.
+// The dataref extension is kept, so the event can be forwarded without moving the data again.
+// The data is encoded as JSON, rather than base64, when the event is structured only if it's valid JSON
+// with a JSON content type, as it was before it was offloaded.
+// The transformers have no context of their own, so ctx is passed to the store.
+func Resolve(ctx context.Context, store BlobStore) binding.TransformerFactory {
+	return binding.EventTransformerFactory(func(e *event.Event) error {
+		ref, ok := extensions.GetDataRef(*e)
+		if !ok || e.Data != nil {
+			return nil
+		}
+		data, err := store.Get(ctx, ref)
+		if err != nil {
+			return err
+		}
+		if err := e.SetData(data); err != nil {
+			return err
+		}
+		e.DataBinary = !isJSON(e.DataMediaType()) || !json.Valid(data)
+		return nil
+	})
+}
+
+// isJSON reports if the data with the media type is encoded as JSON in structured events
+func isJSON(mediaType string) bool {
+	return mediaType == "" || mediaType == event.ApplicationJSON || mediaType == event.TextJSON
+}
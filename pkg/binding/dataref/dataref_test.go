@@ -0,0 +1,142 @@
+package dataref_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/dataref"
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/extensions"
+)
+
+func newFileStore(t *testing.T) (*dataref.FileStore, func()) {
+	dir, err := ioutil.TempDir("", "dataref")
+	require.NoError(t, err)
+	store, err := dataref.NewFileStore(dir)
+	require.NoError(t, err)
+	return store, func() { _ = os.RemoveAll(dir) }
+}
+
+func TestFileStore(t *testing.T) {
+	store, cleanup := newFileStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	ref, err := store.Put(ctx, []byte("hello"))
+	require.NoError(t, err)
+	require.Equal(t, "file", ref.Scheme)
+	data, err := store.Get(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), data)
+
+	for _, ref := range []string{"http://example.com/data", "file:///etc/passwd", ref.String() + "/../../x"} {
+		u, err := url.Parse(ref)
+		require.NoError(t, err)
+		_, err = store.Get(ctx, u)
+		require.Error(t, err, ref)
+	}
+}
+
+func TestOffloadAndResolve(t *testing.T) {
+	store, cleanup := newFileStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Small events are untouched
+	small := test.FullEvent()
+	e, err := binding.ToEvent(ctx, binding.EventMessage(small), binding.TransformerFactories{dataref.Offload(ctx, store, 1024)})
+	require.NoError(t, err)
+	test.AssertEventEquals(t, small, *e)
+
+	big := test.FullEvent()
+	encoded := test.FullEvent()
+	want, err := encoded.DataBytes()
+	require.NoError(t, err)
+	offloaded, err := binding.ToEvent(ctx, binding.EventMessage(big), binding.TransformerFactories{dataref.Offload(ctx, store, len(want)-1)})
+	require.NoError(t, err)
+	require.Nil(t, offloaded.Data)
+	ref, ok := extensions.GetDataRef(*offloaded)
+	require.True(t, ok)
+	data, err := store.Get(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, want, data)
+
+	// Resolve the data of the received message
+	m := test.MustCreateMockBinaryMessage(*offloaded)
+	resolved, err := binding.ToEvent(ctx, m, binding.TransformerFactories{dataref.Resolve(ctx, store)})
+	require.NoError(t, err)
+	data, err = resolved.DataBytes()
+	require.NoError(t, err)
+	require.Equal(t, want, data)
+	_, ok = extensions.GetDataRef(*resolved)
+	require.True(t, ok)
+
+	// The JSON data is encoded as JSON in structured events, as before offloading it
+	b, err := format.JSON.Marshal(*resolved)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"data":"hello"`)
+	require.NotContains(t, string(b), "data_base64")
+
+	// Events without dataref are untouched
+	e, err = binding.ToEvent(ctx, binding.EventMessage(test.MinEvent()), binding.TransformerFactories{dataref.Resolve(ctx, store)})
+	require.NoError(t, err)
+	test.AssertEventEquals(t, test.MinEvent(), *e)
+}
+
+func TestResolveBinaryData(t *testing.T) {
+	store, cleanup := newFileStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	in := test.MinEvent()
+	require.NoError(t, in.SetData([]byte{0, 1, 2, 3}))
+	offloaded, err := binding.ToEvent(ctx, binding.EventMessage(in), binding.TransformerFactories{dataref.Offload(ctx, store, 0)})
+	require.NoError(t, err)
+	resolved, err := binding.ToEvent(ctx, test.MustCreateMockBinaryMessage(*offloaded), binding.TransformerFactories{dataref.Resolve(ctx, store)})
+	require.NoError(t, err)
+	require.True(t, resolved.DataBinary)
+	data, err := resolved.DataBytes()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 1, 2, 3}, data)
+}
+
+// ctxStore fails when its context is done
+type ctxStore struct{ dataref.BlobStore }
+
+func (s ctxStore) Put(ctx context.Context, data []byte) (*url.URL, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.BlobStore.Put(ctx, data)
+}
+
+func (s ctxStore) Get(ctx context.Context, uri *url.URL) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.BlobStore.Get(ctx, uri)
+}
+
+func TestStoreContext(t *testing.T) {
+	fileStore, cleanup := newFileStore(t)
+	defer cleanup()
+	store := ctxStore{fileStore}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := binding.ToEvent(context.Background(), binding.EventMessage(test.FullEvent()), binding.TransformerFactories{dataref.Offload(ctx, store, 0)})
+	require.True(t, errors.Is(err, context.Canceled))
+
+	offloaded, err := binding.ToEvent(context.Background(), binding.EventMessage(test.FullEvent()), binding.TransformerFactories{dataref.Offload(context.Background(), store, 0)})
+	require.NoError(t, err)
+	_, err = binding.ToEvent(context.Background(), test.MustCreateMockBinaryMessage(*offloaded), binding.TransformerFactories{dataref.Resolve(ctx, store)})
+	require.True(t, errors.Is(err, context.Canceled))
+}
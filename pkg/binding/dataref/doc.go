@@ -0,0 +1,12 @@
+/*
+Package dataref implements the claim check pattern with the dataref extension:
+https://github.com/cloudevents/spec/blob/master/extensions/dataref.md
+
+Senders use the Offload transformer to move big payloads to a BlobStore, receivers use the
+Resolve transformer to read them back before the event is handed to the client:
+
+	store, err := dataref.NewFileStore("/var/lib/events")
+	sender, err := kafka_sarama.NewSender(client, topic, kafka_sarama.WithTransformer(dataref.Offload(ctx, store, 64*1024)))
+	event, err := binding.ToEvent(ctx, message, binding.TransformerFactories{dataref.Resolve(ctx, store)})
+*/
+package dataref
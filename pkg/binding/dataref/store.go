@@ -0,0 +1,61 @@
+package dataref
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// BlobStore stores the data of the events referenced with the dataref extension.
+type BlobStore interface {
+	// Put stores data and returns the URI to Get it.
+	Put(ctx context.Context, data []byte) (*url.URL, error)
+
+	// Get returns the data stored at uri.
+	// It returns an error if uri doesn't belong to the store.
+	Get(ctx context.Context, uri *url.URL) ([]byte, error)
+}
+
+// FileStore is a BlobStore storing the data in the files of a directory, referenced with file URIs.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore storing the data in dir, creating it if missing.
+func NewFileStore(dir string) (*FileStore, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Put(_ context.Context, data []byte) (*url.URL, error) {
+	path := filepath.Join(s.dir, uuid.New().String())
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return nil, err
+	}
+	return &url.URL{Scheme: "file", Path: filepath.ToSlash(path)}, nil
+}
+
+func (s *FileStore) Get(_ context.Context, uri *url.URL) ([]byte, error) {
+	if uri.Scheme != "file" {
+		return nil, fmt.Errorf("unsupported dataref scheme %q", uri.Scheme)
+	}
+	path := filepath.Clean(filepath.FromSlash(uri.Path))
+	if !strings.HasPrefix(path, s.dir+string(filepath.Separator)) {
+		return nil, fmt.Errorf("dataref %s is outside of the store directory", uri)
+	}
+	return ioutil.ReadFile(path)
+}
+
+var _ BlobStore = (*FileStore)(nil) // Test it conforms to the interface
@@ -40,6 +40,11 @@ In order to consume a message several times, the binding/buffering module provid
 A message can be converted to an event.Event using binding.ToEvent() method.
 An event.Event can be used as Message casting it to binding.EventMessage.
 
+Several events can be transferred together in a batch message, which reports binding.EncodingBatch encoding
+and implements binding.BatchReader. A batch message can be converted to a slice of event.Event using binding.ToEvents()
+and a slice of event.Event can be used as Message casting it to binding.EventBatchMessage.
+Protocols supporting batches implement binding.BatchWriter.
+
 In order to simplify the encoding process for each protocol, this package provide several utility methods like binding.Write and binding.DirectWrite.
 The binding.Write method tries to preserve the structured/binary encoding, in order to be as much efficient as possible.
 
@@ -14,6 +14,8 @@ const (
 	EncodingEvent
 	// When the encoding is unknown (which means that the message is a non-event)
 	EncodingUnknown
+	// Batch encoding as specified in https://github.com/cloudevents/spec/blob/master/json-format.md#4-json-batch-format
+	EncodingBatch
 )
 
 // Error to specify that or the Message is not an event or it is encoded with an unknown encoding
@@ -24,3 +26,6 @@ var ErrNotStructured = errors.New("message is not in structured mode")
 
 // ErrNotBinary returned by Message.Binary for non-binary messages.
 var ErrNotBinary = errors.New("message is not in binary mode")
+
+// ErrNotBatch returned by Message.ReadBatch for non-batch messages.
+var ErrNotBatch = errors.New("message is not in batch mode")
@@ -0,0 +1,17 @@
+/*
+Package encryption encrypts the data of the events with AES-GCM, using envelope encryption.
+
+The data of each event is encrypted with a new random data key. The data key is encrypted with a
+key of a KeyProvider, the key encryption key, and it's stored with the ID of the key encryption key
+in the extensions encryptionkey and encryptionkeyid. The attributes are not encrypted, but the key ID,
+the id and the source are authenticated with the data and with the data key: the encrypted data can't be
+decrypted if it's copied to another event.
+
+Encrypt and Decrypt return transformers for the senders and the receivers:
+
+	keys, err := encryption.NewStaticKeyProvider("key-2020", map[string][]byte{"key-2020": key})
+	sender, err := kafka_sarama.NewSender(client, topic, kafka_sarama.WithTransformer(encryption.Encrypt(keys)))
+
+	event, err := binding.ToEvent(ctx, message, binding.TransformerFactories{encryption.Decrypt(keys)})
+*/
+package encryption
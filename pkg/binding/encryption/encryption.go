@@ -0,0 +1,310 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/spec"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/types"
+)
+
+const (
+	// EncryptionKeyIDExtension holds the ID of the key encryption key
+	EncryptionKeyIDExtension = "encryptionkeyid"
+	// EncryptionKeyExtension holds the base64 encoded encrypted data key
+	EncryptionKeyExtension = "encryptionkey"
+
+	dataKeySize = 32 // AES-256
+)
+
+// ErrUnknownKey is returned when the key encryption key is unknown
+var ErrUnknownKey = errors.New("unknown encryption key")
+
+// Encrypt returns a transformer which encrypts the data of the events with the keys of keys.
+// Events without data, or already encrypted, are left unchanged.
+func Encrypt(keys KeyProvider) binding.TransformerFactory {
+	return encryptTransformerFactory{keys: keys}
+}
+
+// Decrypt returns a transformer which decrypts the data of the events encrypted with Encrypt,
+// removing the encryption extensions.
+// Events without the encryption extensions are left unchanged.
+func Decrypt(keys KeyProvider) binding.TransformerFactory {
+	return decryptTransformerFactory{keys: keys}
+}
+
+// encrypt encrypts the data of the event with the id and the source.
+// The key ID, the id and the source are authenticated with the data and with the data key,
+// so the ciphertext can't be moved to another event or paired with another key.
+func encrypt(keys KeyProvider, id string, source string, data []byte) (keyID string, wrappedKey string, ciphertext []byte, err error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err = io.ReadFull(rand.Reader, dataKey); err != nil {
+		return
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return
+	}
+	keyID = keys.KeyID()
+	aad := associatedData(keyID, id, source)
+	if ciphertext, err = seal(aead, data, aad); err != nil {
+		return
+	}
+	wrapped, err := keys.WrapKey(keyID, dataKey, aad)
+	if err != nil {
+		return
+	}
+	return keyID, base64.StdEncoding.EncodeToString(wrapped), ciphertext, nil
+}
+
+func decrypt(keys KeyProvider, keyID string, wrappedKey string, id string, source string, ciphertext []byte) ([]byte, error) {
+	wrapped, err := base64.StdEncoding.DecodeString(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	aad := associatedData(keyID, id, source)
+	dataKey, err := keys.UnwrapKey(keyID, wrapped, aad)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, ciphertext, aad)
+}
+
+// associatedData returns the values prefixed with their length, so they can't be shifted from one to another.
+func associatedData(values ...string) []byte {
+	var aad []byte
+	length := make([]byte, binary.MaxVarintLen64)
+	for _, v := range values {
+		n := binary.PutUvarint(length, uint64(len(v)))
+		aad = append(aad, length[:n]...)
+		aad = append(aad, v...)
+	}
+	return aad
+}
+
+type encryptTransformerFactory struct {
+	keys KeyProvider
+}
+
+func (f encryptTransformerFactory) StructuredTransformer(binding.StructuredWriter) binding.StructuredWriter {
+	return nil
+}
+
+func (f encryptTransformerFactory) BinaryTransformer(encoder binding.BinaryWriter) binding.BinaryWriter {
+	return &encryptTransformer{BinaryWriter: encoder, keys: f.keys}
+}
+
+func (f encryptTransformerFactory) EventTransformer() binding.EventTransformer {
+	return func(e *event.Event) error {
+		if _, ok := e.Extensions()[EncryptionKeyExtension]; ok {
+			return nil
+		}
+		data, err := e.DataBytes()
+		if err != nil || len(data) == 0 {
+			return err
+		}
+		keyID, wrappedKey, ciphertext, err := encrypt(f.keys, e.ID(), e.Source(), data)
+		if err != nil {
+			return err
+		}
+		if err := e.Context.SetExtension(EncryptionKeyIDExtension, keyID); err != nil {
+			return err
+		}
+		if err := e.Context.SetExtension(EncryptionKeyExtension, wrappedKey); err != nil {
+			return err
+		}
+		return e.SetData(ciphertext)
+	}
+}
+
+// encryptTransformer holds the data until End, to add the encryption extensions before it.
+type encryptTransformer struct {
+	binding.BinaryWriter
+	keys      KeyProvider
+	encrypted bool
+	id        interface{}
+	source    interface{}
+	data      []byte
+}
+
+func (b *encryptTransformer) SetAttribute(attribute spec.Attribute, value interface{}) error {
+	switch attribute.Kind() {
+	case spec.ID:
+		b.id = value
+	case spec.Source:
+		b.source = value
+	}
+	return b.BinaryWriter.SetAttribute(attribute, value)
+}
+
+func (b *encryptTransformer) SetExtension(name string, value interface{}) error {
+	if name == EncryptionKeyExtension {
+		b.encrypted = true
+	}
+	return b.BinaryWriter.SetExtension(name, value)
+}
+
+func (b *encryptTransformer) SetData(data io.Reader) (err error) {
+	b.data, err = ioutil.ReadAll(data)
+	return err
+}
+
+func (b *encryptTransformer) End() error {
+	if len(b.data) > 0 {
+		data := b.data
+		if !b.encrypted {
+			id, source, err := formatIDAndSource(b.id, b.source)
+			if err != nil {
+				return err
+			}
+			keyID, wrappedKey, ciphertext, err := encrypt(b.keys, id, source, data)
+			if err != nil {
+				return err
+			}
+			if err := b.BinaryWriter.SetExtension(EncryptionKeyIDExtension, keyID); err != nil {
+				return err
+			}
+			if err := b.BinaryWriter.SetExtension(EncryptionKeyExtension, wrappedKey); err != nil {
+				return err
+			}
+			data = ciphertext
+		}
+		if err := b.BinaryWriter.SetData(bytes.NewReader(data)); err != nil {
+			return err
+		}
+	}
+	return b.BinaryWriter.End()
+}
+
+type decryptTransformerFactory struct {
+	keys KeyProvider
+}
+
+func (f decryptTransformerFactory) StructuredTransformer(binding.StructuredWriter) binding.StructuredWriter {
+	return nil
+}
+
+func (f decryptTransformerFactory) BinaryTransformer(encoder binding.BinaryWriter) binding.BinaryWriter {
+	return &decryptTransformer{BinaryWriter: encoder, keys: f.keys}
+}
+
+func (f decryptTransformerFactory) EventTransformer() binding.EventTransformer {
+	return func(e *event.Event) error {
+		wrappedKey, ok := e.Extensions()[EncryptionKeyExtension]
+		if !ok {
+			return nil
+		}
+		keyID := e.Extensions()[EncryptionKeyIDExtension]
+		ciphertext, err := e.DataBytes()
+		if err != nil {
+			return err
+		}
+		data, err := decryptValues(f.keys, keyID, wrappedKey, e.ID(), e.Source(), ciphertext)
+		if err != nil {
+			return err
+		}
+		if err := e.Context.SetExtension(EncryptionKeyIDExtension, nil); err != nil {
+			return err
+		}
+		if err := e.Context.SetExtension(EncryptionKeyExtension, nil); err != nil {
+			return err
+		}
+		return e.SetData(data)
+	}
+}
+
+func decryptValues(keys KeyProvider, keyID interface{}, wrappedKey interface{}, id interface{}, source interface{}, ciphertext []byte) ([]byte, error) {
+	keyIDStr, err := types.ToString(keyID)
+	if err != nil {
+		return nil, err
+	}
+	wrappedKeyStr, err := types.ToString(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	idStr, sourceStr, err := formatIDAndSource(id, source)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(keys, keyIDStr, wrappedKeyStr, idStr, sourceStr, ciphertext)
+}
+
+// formatIDAndSource returns the canonical string encoding of the id and of the source,
+// as received by a BinaryWriter.
+func formatIDAndSource(id interface{}, source interface{}) (string, string, error) {
+	idStr, err := types.Format(id)
+	if err != nil {
+		return "", "", err
+	}
+	sourceStr, err := types.Format(source)
+	if err != nil {
+		return "", "", err
+	}
+	return idStr, sourceStr, nil
+}
+
+// decryptTransformer holds the encryption extensions and the data until End,
+// because they can be written in any order.
+type decryptTransformer struct {
+	binding.BinaryWriter
+	keys       KeyProvider
+	keyID      interface{}
+	wrappedKey interface{}
+	id         interface{}
+	source     interface{}
+	data       []byte
+}
+
+func (b *decryptTransformer) SetAttribute(attribute spec.Attribute, value interface{}) error {
+	switch attribute.Kind() {
+	case spec.ID:
+		b.id = value
+	case spec.Source:
+		b.source = value
+	}
+	return b.BinaryWriter.SetAttribute(attribute, value)
+}
+
+func (b *decryptTransformer) SetExtension(name string, value interface{}) error {
+	switch name {
+	case EncryptionKeyIDExtension:
+		b.keyID = value
+		return nil
+	case EncryptionKeyExtension:
+		b.wrappedKey = value
+		return nil
+	}
+	return b.BinaryWriter.SetExtension(name, value)
+}
+
+func (b *decryptTransformer) SetData(data io.Reader) (err error) {
+	b.data, err = ioutil.ReadAll(data)
+	return err
+}
+
+func (b *decryptTransformer) End() error {
+	data := b.data
+	if b.wrappedKey != nil && len(data) > 0 {
+		var err error
+		if data, err = decryptValues(b.keys, b.keyID, b.wrappedKey, b.id, b.source, data); err != nil {
+			return err
+		}
+	}
+	if len(data) > 0 {
+		if err := b.BinaryWriter.SetData(bytes.NewReader(data)); err != nil {
+			return err
+		}
+	}
+	return b.BinaryWriter.End()
+}
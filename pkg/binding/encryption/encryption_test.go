@@ -0,0 +1,166 @@
+package encryption_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/encryption"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+var (
+	oldKey = bytes.Repeat([]byte{1}, 32)
+	newKey = bytes.Repeat([]byte{2}, 16)
+)
+
+func keyProvider(t *testing.T, keyID string) encryption.KeyProvider {
+	keys, err := encryption.NewStaticKeyProvider(keyID, map[string][]byte{"old": oldKey, "new": newKey})
+	require.NoError(t, err)
+	return keys
+}
+
+// transform writes m in mock messages with the transformers, and converts the result to an event.
+func transform(t *testing.T, m binding.Message, transformers ...binding.TransformerFactory) (event.Event, error) {
+	ctx := context.Background()
+	mockStructured := test.MockStructuredMessage{}
+	mockBinary := test.MockBinaryMessage{}
+	enc, err := binding.Write(ctx, m, &mockStructured, &mockBinary, transformers)
+	if err != nil {
+		return event.Event{}, err
+	}
+	var e *event.Event
+	if enc == binding.EncodingStructured {
+		e, err = binding.ToEvent(ctx, &mockStructured, nil)
+	} else {
+		e, err = binding.ToEvent(ctx, &mockBinary, nil)
+	}
+	require.NoError(t, err)
+	return *e, nil
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	keys := keyProvider(t, "new")
+	e := test.FullEvent()
+	plaintext, err := e.DataBytes()
+	require.NoError(t, err)
+
+	messages := map[string]func(event.Event) binding.Message{
+		"binary":     test.MustCreateMockBinaryMessage,
+		"structured": test.MustCreateMockStructuredMessage,
+		"event":      func(e event.Event) binding.Message { return binding.EventMessage(e) },
+	}
+	for name, toMessage := range messages {
+		t.Run(name, func(t *testing.T) {
+			encrypted, err := transform(t, toMessage(test.CopyEventContext(e)), encryption.Encrypt(keys))
+			require.NoError(t, err)
+			require.Equal(t, "new", encrypted.Extensions()[encryption.EncryptionKeyIDExtension])
+			require.Contains(t, encrypted.Extensions(), encryption.EncryptionKeyExtension)
+			ciphertext, err := encrypted.DataBytes()
+			require.NoError(t, err)
+			require.NotContains(t, string(ciphertext), string(plaintext))
+
+			// Encrypting twice doesn't change the event
+			again, err := transform(t, binding.EventMessage(test.CopyEventContext(encrypted)), encryption.Encrypt(keys))
+			require.NoError(t, err)
+			test.AssertEventEquals(t, encrypted, again)
+
+			for name, toMessage := range messages {
+				t.Run(name, func(t *testing.T) {
+					decrypted, err := transform(t, toMessage(test.CopyEventContext(encrypted)), encryption.Decrypt(keys))
+					require.NoError(t, err)
+					require.NotContains(t, decrypted.Extensions(), encryption.EncryptionKeyIDExtension)
+					require.NotContains(t, decrypted.Extensions(), encryption.EncryptionKeyExtension)
+					require.Equal(t, e.ID(), decrypted.ID())
+					require.Equal(t, e.Subject(), decrypted.Subject())
+					data, err := decrypted.DataBytes()
+					require.NoError(t, err)
+					require.Equal(t, plaintext, data)
+				})
+			}
+		})
+	}
+}
+
+func TestDecryptNotEncrypted(t *testing.T) {
+	keys := keyProvider(t, "new")
+	e := test.FullEvent()
+	have, err := transform(t, test.MustCreateMockBinaryMessage(e), encryption.Decrypt(keys))
+	require.NoError(t, err)
+	test.AssertEventEquals(t, e, have)
+
+	have, err = transform(t, binding.EventMessage(test.MinEvent()), encryption.Encrypt(keys))
+	require.NoError(t, err)
+	require.NotContains(t, have.Extensions(), encryption.EncryptionKeyExtension)
+}
+
+func TestKeyRotation(t *testing.T) {
+	e := test.FullEvent()
+	encrypted, err := transform(t, binding.EventMessage(e), encryption.Encrypt(keyProvider(t, "old")))
+	require.NoError(t, err)
+	require.Equal(t, "old", encrypted.Extensions()[encryption.EncryptionKeyIDExtension])
+
+	_, err = transform(t, binding.EventMessage(test.CopyEventContext(encrypted)), encryption.Decrypt(keyProvider(t, "new")))
+	require.NoError(t, err)
+
+	onlyNew, err := encryption.NewStaticKeyProvider("new", map[string][]byte{"new": newKey})
+	require.NoError(t, err)
+	_, err = transform(t, binding.EventMessage(encrypted), encryption.Decrypt(onlyNew))
+	require.True(t, errors.Is(err, encryption.ErrUnknownKey))
+	_, err = transform(t, test.MustCreateMockBinaryMessage(encrypted), encryption.Decrypt(onlyNew))
+	require.True(t, errors.Is(err, encryption.ErrUnknownKey))
+}
+
+func TestTampered(t *testing.T) {
+	keys := keyProvider(t, "new")
+	encrypted, err := transform(t, binding.EventMessage(test.FullEvent()), encryption.Encrypt(keys))
+	require.NoError(t, err)
+
+	tampered := test.CopyEventContext(encrypted)
+	ciphertext, err := tampered.DataBytes()
+	require.NoError(t, err)
+	ciphertext = append([]byte{}, ciphertext...)
+	ciphertext[len(ciphertext)-1] ^= 1
+	require.NoError(t, tampered.SetData(ciphertext))
+	_, err = transform(t, binding.EventMessage(tampered), encryption.Decrypt(keys))
+	require.Error(t, err)
+
+	tampered = test.CopyEventContext(encrypted)
+	tampered.SetExtension(encryption.EncryptionKeyExtension, "bm90IGEga2V5")
+	_, err = transform(t, test.MustCreateMockBinaryMessage(tampered), encryption.Decrypt(keys))
+	require.Error(t, err)
+}
+
+func TestAssociatedData(t *testing.T) {
+	keys := keyProvider(t, "new")
+	encrypted, err := transform(t, binding.EventMessage(test.FullEvent()), encryption.Encrypt(keys))
+	require.NoError(t, err)
+
+	// The encrypted data and key are bound to the id, the source and the key ID of the event
+	for name, tamper := range map[string]func(*event.Event){
+		"id":     func(e *event.Event) { e.SetID("other-id") },
+		"source": func(e *event.Event) { e.SetSource("/other/source") },
+		"key id": func(e *event.Event) { e.SetExtension(encryption.EncryptionKeyIDExtension, "old") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			moved := test.CopyEventContext(encrypted)
+			tamper(&moved)
+			_, err := transform(t, binding.EventMessage(moved), encryption.Decrypt(keys))
+			require.Error(t, err)
+			_, err = transform(t, test.MustCreateMockBinaryMessage(moved), encryption.Decrypt(keys))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewStaticKeyProvider(t *testing.T) {
+	_, err := encryption.NewStaticKeyProvider("missing", map[string][]byte{"new": newKey})
+	require.Error(t, err)
+	_, err = encryption.NewStaticKeyProvider("short", map[string][]byte{"short": []byte("short")})
+	require.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// KeyProvider encrypts and decrypts the data keys with the key encryption keys.
+type KeyProvider interface {
+	// KeyID returns the ID of the key encrypting the data keys of new events.
+	KeyID() string
+	// WrapKey encrypts dataKey with the key identified by keyID, authenticating aad with it.
+	WrapKey(keyID string, dataKey []byte, aad []byte) ([]byte, error)
+	// UnwrapKey decrypts wrappedKey with the key identified by keyID.
+	// It fails if aad isn't the one passed to WrapKey.
+	UnwrapKey(keyID string, wrappedKey []byte, aad []byte) ([]byte, error)
+}
+
+// NewStaticKeyProvider returns a KeyProvider wrapping the data keys with AES-GCM, using the AES keys
+// of keys. The key keyID wraps the data keys of new events, the other keys are used to decrypt
+// events encrypted before a key rotation.
+func NewStaticKeyProvider(keyID string, keys map[string][]byte) (KeyProvider, error) {
+	if _, ok := keys[keyID]; !ok {
+		return nil, fmt.Errorf("missing key %q", keyID)
+	}
+	p := staticKeyProvider{keyID: keyID, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %v", id, err)
+		}
+		p.keys[id] = aead
+	}
+	return p, nil
+}
+
+type staticKeyProvider struct {
+	keyID string
+	keys  map[string]cipher.AEAD
+}
+
+func (p staticKeyProvider) KeyID() string { return p.keyID }
+
+func (p staticKeyProvider) WrapKey(keyID string, dataKey []byte, aad []byte) ([]byte, error) {
+	aead, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, keyID)
+	}
+	return seal(aead, dataKey, aad)
+}
+
+func (p staticKeyProvider) UnwrapKey(keyID string, wrappedKey []byte, aad []byte) ([]byte, error) {
+	aead, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, keyID)
+	}
+	return open(aead, wrappedKey, aad)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext and authenticates aad, prepending the random nonce to the ciphertext.
+func seal(aead cipher.AEAD, plaintext []byte, aad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open decrypts the output of seal, authenticating the same aad.
+func open(aead cipher.AEAD, ciphertext []byte, aad []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce := ciphertext[:aead.NonceSize()]
+	return aead.Open(nil, nonce, ciphertext[aead.NonceSize():], aad)
+}
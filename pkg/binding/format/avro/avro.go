@@ -0,0 +1,383 @@
+package avro
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+	"github.com/cloudevents/sdk-go/pkg/binding/spec"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/types"
+)
+
+// Schema is the CloudEvent Avro schema, as defined by the CloudEvents Avro
+// Event Format specification.
+const Schema = `{
+  "namespace": "io.cloudevents",
+  "type": "record",
+  "name": "CloudEvent",
+  "version": "1.0",
+  "doc": "Avro Event Format for CloudEvents",
+  "fields": [
+    {
+      "name": "attribute",
+      "type": {"type": "map", "values": ["null", "boolean", "int", "string", "bytes"]}
+    },
+    {
+      "name": "data",
+      "type": [
+        "bytes",
+        "null",
+        "boolean",
+        {
+          "type": "map",
+          "values": [
+            "null",
+            "boolean",
+            {
+              "type": "record",
+              "name": "CloudEventData",
+              "doc": "Representation of a JSON Value",
+              "fields": [
+                {
+                  "name": "value",
+                  "type": {
+                    "type": "map",
+                    "values": [
+                      "null",
+                      "boolean",
+                      {"type": "map", "values": "CloudEventData"},
+                      {"type": "array", "items": "CloudEventData"},
+                      "double",
+                      "string"
+                    ]
+                  }
+                }
+              ]
+            },
+            "double",
+            "string"
+          ]
+        },
+        {"type": "array", "items": "CloudEventData"},
+        "double",
+        "string"
+      ]
+    }
+  ]
+}`
+
+// Branches of the attribute value union.
+const (
+	attributeNull = iota
+	attributeBoolean
+	attributeInt
+	attributeString
+	attributeBytes
+)
+
+// Branches of the data union.
+const (
+	dataBytes = iota
+	dataNull
+	dataBoolean
+	dataMap
+	dataArray
+	dataDouble
+	dataString
+)
+
+// Avro is the "application/cloudevents+avro" format.
+var Avro = avroFmt{}
+
+func init() {
+	format.Add(Avro)
+}
+
+type avroFmt struct{}
+
+func (avroFmt) MediaType() string { return event.ApplicationCloudEventsAvro }
+
+func (avroFmt) Marshal(e event.Event) ([]byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	version := spec.VS.Version(e.SpecVersion())
+	if version == nil {
+		return nil, fmt.Errorf("unknown spec version: %q", e.SpecVersion())
+	}
+
+	attrs := make(map[string]interface{}, len(e.Extensions())+len(version.Attributes()))
+	for k, v := range e.Extensions() {
+		attrs[k] = v
+	}
+	for _, a := range version.Attributes() {
+		if v := a.Get(e.Context); v != nil {
+			attrs[a.Name()] = v
+		}
+	}
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Deterministic output
+
+	var enc encoder
+	enc.long(int64(len(names)))
+	for _, name := range names {
+		enc.string(name)
+		if err := encodeAttribute(&enc, attrs[name]); err != nil {
+			return nil, fmt.Errorf("invalid value for attribute %s: %v", name, err)
+		}
+	}
+	enc.long(0)
+
+	if e.Data == nil {
+		enc.long(dataNull)
+		return enc.buf, nil
+	}
+	data, err := e.DataBytes()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case e.DeprecatedDataContentEncoding() == event.Base64:
+		if data, err = base64.StdEncoding.DecodeString(string(data)); err != nil {
+			return nil, err
+		}
+		enc.long(dataBytes)
+		enc.bytes(data)
+	case e.DataBinary:
+		enc.long(dataBytes)
+		enc.bytes(data)
+	default:
+		enc.long(dataString)
+		enc.string(string(data))
+	}
+	return enc.buf, nil
+}
+
+// encodeAttribute encodes v as a branch of the attribute value union.
+// Values without a matching Avro type use their canonical string form.
+func encodeAttribute(enc *encoder, v interface{}) error {
+	v, err := types.Validate(v)
+	if err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		enc.long(attributeBoolean)
+		enc.boolean(v)
+	case int32:
+		enc.long(attributeInt)
+		enc.long(int64(v))
+	case string:
+		enc.long(attributeString)
+		enc.string(v)
+	case []byte:
+		enc.long(attributeBytes)
+		enc.bytes(v)
+	default:
+		s, err := types.Format(v)
+		if err != nil {
+			return err
+		}
+		enc.long(attributeString)
+		enc.string(s)
+	}
+	return nil
+}
+
+func (avroFmt) Unmarshal(b []byte, e *event.Event) error {
+	d := decoder{buf: b}
+
+	attrs := map[string]interface{}{}
+	err := d.mapEntries(func(name string) error {
+		v, err := decodeAttribute(&d)
+		if v != nil {
+			attrs[name] = v
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	sv, _ := attrs["specversion"].(string)
+	version := spec.VS.Version(sv)
+	if version == nil {
+		return fmt.Errorf("unknown spec version: %q", sv)
+	}
+	delete(attrs, "specversion")
+	c := version.NewContext()
+	for name, value := range attrs {
+		if err := version.SetAttribute(c, name, value); err != nil {
+			return err
+		}
+	}
+
+	var (
+		data       []byte
+		dataBinary bool
+		value      interface{}
+	)
+	branch, err := d.long()
+	if err != nil {
+		return err
+	}
+	switch branch {
+	case dataBytes:
+		data, err = d.bytes()
+		dataBinary = true
+	case dataNull:
+	case dataString:
+		data, err = d.bytes()
+	case dataBoolean:
+		value, err = d.boolean()
+	case dataMap:
+		value, err = decodeDataMap(&d)
+	case dataArray:
+		value, err = decodeDataArray(&d)
+	case dataDouble:
+		value, err = d.double()
+	default:
+		return unionErr("data", branch)
+	}
+	if err != nil {
+		return err
+	}
+	if len(d.buf) != 0 {
+		return errors.New("avro: unexpected bytes after CloudEvent record")
+	}
+	if value != nil {
+		// A JSON value, represented with Avro types
+		if data, err = json.Marshal(value); err != nil {
+			return err
+		}
+		if c.GetDataContentType() == "" {
+			if err := c.SetDataContentType(event.ApplicationJSON); err != nil {
+				return err
+			}
+		}
+	}
+
+	*e = event.Event{Context: c}
+	if data != nil {
+		e.Data = data
+		e.DataEncoded = true
+		e.DataBinary = dataBinary
+	}
+	return nil
+}
+
+// decodeAttribute decodes a branch of the attribute value union.
+func decodeAttribute(d *decoder) (interface{}, error) {
+	branch, err := d.long()
+	if err != nil {
+		return nil, err
+	}
+	switch branch {
+	case attributeNull:
+		return nil, nil
+	case attributeBoolean:
+		return d.boolean()
+	case attributeInt:
+		v, err := d.long()
+		if err != nil {
+			return nil, err
+		}
+		if v > math.MaxInt32 || v < math.MinInt32 {
+			return nil, fmt.Errorf("avro: int value %d out of range", v)
+		}
+		return int32(v), nil
+	case attributeString:
+		return d.string()
+	case attributeBytes:
+		return d.bytes()
+	default:
+		return nil, unionErr("attribute", branch)
+	}
+}
+
+// decodeDataMap decodes the map branch of the data union to a JSON object.
+func decodeDataMap(d *decoder) (map[string]interface{}, error) {
+	obj := map[string]interface{}{}
+	err := d.mapEntries(func(key string) error {
+		branch, err := d.long()
+		if err != nil {
+			return err
+		}
+		var v interface{}
+		switch branch {
+		case 0: // null
+		case 1:
+			v, err = d.boolean()
+		case 2:
+			v, err = decodeCloudEventData(d)
+		case 3:
+			v, err = d.double()
+		case 4:
+			v, err = d.string()
+		default:
+			return unionErr("data map", branch)
+		}
+		obj[key] = v
+		return err
+	})
+	return obj, err
+}
+
+// decodeDataArray decodes an array of CloudEventData to a JSON array.
+func decodeDataArray(d *decoder) ([]interface{}, error) {
+	arr := []interface{}{}
+	err := d.blocks(func() error {
+		v, err := decodeCloudEventData(d)
+		arr = append(arr, v)
+		return err
+	})
+	return arr, err
+}
+
+// decodeCloudEventData decodes a CloudEventData record to a JSON object.
+func decodeCloudEventData(d *decoder) (map[string]interface{}, error) {
+	obj := map[string]interface{}{}
+	err := d.mapEntries(func(key string) error {
+		branch, err := d.long()
+		if err != nil {
+			return err
+		}
+		var v interface{}
+		switch branch {
+		case 0: // null
+		case 1:
+			v, err = d.boolean()
+		case 2:
+			nested := map[string]interface{}{}
+			err = d.mapEntries(func(key string) error {
+				var err error
+				nested[key], err = decodeCloudEventData(d)
+				return err
+			})
+			v = nested
+		case 3:
+			v, err = decodeDataArray(d)
+		case 4:
+			v, err = d.double()
+		case 5:
+			v, err = d.string()
+		default:
+			return unionErr("CloudEventData", branch)
+		}
+		obj[key] = v
+		return err
+	})
+	return obj, err
+}
+
+func unionErr(name string, branch int64) error {
+	return fmt.Errorf("avro: invalid %s union branch %d", name, branch)
+}
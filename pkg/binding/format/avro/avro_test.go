@@ -0,0 +1,109 @@
+package avro_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+	"github.com/cloudevents/sdk-go/pkg/binding/format/avro"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+func TestLookup(t *testing.T) {
+	f := format.Lookup(event.ApplicationCloudEventsAvro)
+	require.Equal(t, avro.Avro, f)
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	test.EachEvent(t, append(test.Events(), binaryDataEvent()), func(t *testing.T, e event.Event) {
+		b, err := format.Marshal(event.ApplicationCloudEventsAvro, e)
+		require.NoError(t, err)
+
+		var have event.Event
+		require.NoError(t, format.Unmarshal(event.ApplicationCloudEventsAvro, b, &have))
+		// URI and timestamp extensions are encoded as strings
+		test.AssertEventEquals(t, test.ExToStr(t, e), test.ExToStr(t, have))
+		require.Equal(t, e.DataBinary, have.DataBinary)
+	})
+}
+
+func TestAttributeTypes(t *testing.T) {
+	b, err := avro.Avro.Marshal(test.FullEvent())
+	require.NoError(t, err)
+
+	var e event.Event
+	require.NoError(t, avro.Avro.Unmarshal(b, &e))
+	require.Equal(t, true, e.Extensions()["exbool"])
+	require.Equal(t, int32(42), e.Extensions()["exint"])
+	require.Equal(t, "exstring", e.Extensions()["exstring"])
+	require.Equal(t, []byte{0, 1, 2, 3}, e.Extensions()["exbinary"])
+	require.Equal(t, test.Source.String(), e.Extensions()["exurl"])
+	require.Equal(t, test.Timestamp.Time, e.Time())
+}
+
+func TestUnmarshalJSONData(t *testing.T) {
+	var b []byte
+	long := func(v int64) {
+		var buf [binary.MaxVarintLen64]byte
+		b = append(b, buf[:binary.PutVarint(buf[:], v)]...)
+	}
+	str := func(s string) {
+		long(int64(len(s)))
+		b = append(b, s...)
+	}
+
+	long(4)
+	for _, kv := range [][2]string{{"specversion", "1.0"}, {"id", "id"}, {"source", "source"}, {"type", "type"}} {
+		str(kv[0])
+		long(3) // string
+		str(kv[1])
+	}
+	long(0)
+	// data: {"a": true, "b": {"c": "d"}, "e": null}
+	long(3) // map
+	long(3)
+	str("a")
+	long(1) // boolean
+	b = append(b, 1)
+	str("b")
+	long(2) // CloudEventData
+	long(1)
+	str("c")
+	long(5) // string
+	str("d")
+	long(0)
+	str("e")
+	long(0) // null
+	long(0)
+
+	var e event.Event
+	require.NoError(t, avro.Avro.Unmarshal(b, &e))
+	require.Equal(t, "id", e.ID())
+	require.Equal(t, event.ApplicationJSON, e.DataContentType())
+	data, err := e.DataBytes()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a": true, "b": {"c": "d"}, "e": null}`, string(data))
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	b, err := avro.Avro.Marshal(test.FullEvent())
+	require.NoError(t, err)
+
+	var e event.Event
+	require.Error(t, avro.Avro.Unmarshal(b[:len(b)-1], &e))
+	require.Error(t, avro.Avro.Unmarshal(append(b, 0), &e))
+	require.Error(t, avro.Avro.Unmarshal([]byte{0, 2}, &e))
+}
+
+func binaryDataEvent() event.Event {
+	e := test.FullEvent()
+	e.SetID("binary-data")
+	e.SetDataContentType("application/octet-stream")
+	if err := e.SetData([]byte{0, 1, 2, 3}); err != nil {
+		panic(err)
+	}
+	return e
+}
@@ -0,0 +1,16 @@
+/*
+Package avro implements the "application/cloudevents+avro" event format,
+encoding events as records of the CloudEvent Avro Schema defined by the
+CloudEvents Avro Event Format specification.
+
+Importing the package registers the format with format.Add:
+
+	import _ "github.com/cloudevents/sdk-go/pkg/binding/format/avro"
+
+All context attributes, including extensions, are encoded in the attribute
+map. Boolean, integer, string and binary values keep their type, other values
+are encoded with their canonical string representation. Binary data is encoded
+as bytes and any other data as string. When decoding, data encoded as a JSON
+value is converted back to JSON.
+*/
+package avro
@@ -0,0 +1,123 @@
+package avro
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var errTruncated = errors.New("avro: truncated message")
+
+// encoder appends Avro binary encoded values to a byte slice.
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) long(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(b[:], v) // zig-zag encoded, as Avro does
+	e.buf = append(e.buf, b[:n]...)
+}
+
+func (e *encoder) boolean(v bool) {
+	if v {
+		e.buf = append(e.buf, 1)
+	} else {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *encoder) bytes(b []byte) {
+	e.long(int64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *encoder) string(s string) {
+	e.long(int64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+// decoder reads Avro binary encoded values from a byte slice.
+type decoder struct {
+	buf []byte
+}
+
+func (d *decoder) long() (int64, error) {
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		return 0, errTruncated
+	}
+	d.buf = d.buf[n:]
+	return v, nil
+}
+
+func (d *decoder) boolean() (bool, error) {
+	if len(d.buf) < 1 {
+		return false, errTruncated
+	}
+	v := d.buf[0] != 0
+	d.buf = d.buf[1:]
+	return v, nil
+}
+
+func (d *decoder) double() (float64, error) {
+	if len(d.buf) < 8 {
+		return 0, errTruncated
+	}
+	v := math.Float64frombits(binary.LittleEndian.Uint64(d.buf))
+	d.buf = d.buf[8:]
+	return v, nil
+}
+
+func (d *decoder) bytes() ([]byte, error) {
+	l, err := d.long()
+	if err != nil {
+		return nil, err
+	}
+	if l < 0 || l > int64(len(d.buf)) {
+		return nil, errTruncated
+	}
+	b := d.buf[:l:l]
+	d.buf = d.buf[l:]
+	return b, nil
+}
+
+func (d *decoder) string() (string, error) {
+	b, err := d.bytes()
+	return string(b), err
+}
+
+// blocks reads the blocks of a map or an array, calling item for each item.
+func (d *decoder) blocks(item func() error) error {
+	for {
+		n, err := d.long()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+		if n < 0 { // Negative count is followed by the block size in bytes
+			n = -n
+			if _, err := d.long(); err != nil {
+				return err
+			}
+		}
+		for ; n > 0; n-- {
+			if err := item(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// mapEntries reads the entries of a map, calling value to read each value.
+func (d *decoder) mapEntries(value func(key string) error) error {
+	return d.blocks(func() error {
+		key, err := d.string()
+		if err != nil {
+			return err
+		}
+		return value(key)
+	})
+}
@@ -62,12 +62,67 @@ func (jsonFmt) Unmarshal(b []byte, e *event.Event) error {
 	return nil
 }
 
+// BatchFormat marshals and unmarshals batches of structured events to bytes.
+// A BatchFormat is also a Format: single events are handled as a batch of one event.
+type BatchFormat interface {
+	Format
+	// MarshalBatch events to bytes
+	MarshalBatch([]event.Event) ([]byte, error)
+	// UnmarshalBatch bytes to events
+	UnmarshalBatch([]byte) ([]event.Event, error)
+}
+
+// JSONBatch is the built-in "application/cloudevents-batch+json" format.
+var JSONBatch = jsonBatchFmt{}
+
+type jsonBatchFmt struct{}
+
+func (jsonBatchFmt) MediaType() string { return event.ApplicationCloudEventsBatchJSON }
+
+func (f jsonBatchFmt) Marshal(e event.Event) ([]byte, error) {
+	return f.MarshalBatch([]event.Event{e})
+}
+
+func (f jsonBatchFmt) Unmarshal(b []byte, e *event.Event) error {
+	events, err := f.UnmarshalBatch(b)
+	if err != nil {
+		return err
+	}
+	if len(events) != 1 {
+		return fmt.Errorf("expected a batch with exactly one event, found %d events", len(events))
+	}
+	*e = events[0]
+	return nil
+}
+
+func (jsonBatchFmt) MarshalBatch(events []event.Event) ([]byte, error) {
+	if events == nil {
+		events = []event.Event{}
+	}
+	return json.Marshal(events)
+}
+
+func (jsonBatchFmt) UnmarshalBatch(b []byte) ([]event.Event, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	events := make([]event.Event, len(raw))
+	for i, r := range raw {
+		if err := JSON.Unmarshal(r, &events[i]); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
 // built-in formats
 var formats map[string]Format
 
 func init() {
 	formats = map[string]Format{}
 	Add(JSON)
+	Add(JSONBatch)
 }
 
 // Lookup returns the format for mediaType, or nil if not found.
@@ -77,6 +132,15 @@ func unknown(mediaType string) error {
 	return fmt.Errorf("unknown event format media-type %#v", mediaType)
 }
 
+// LookupBatch returns the batch format for mediaType, or nil if not found
+// or if the format registered for mediaType doesn't support batches.
+func LookupBatch(mediaType string) BatchFormat {
+	if f, ok := formats[mediaType].(BatchFormat); ok {
+		return f
+	}
+	return nil
+}
+
 // Add a new Format. It can be retrieved by Lookup(f.MediaType())
 func Add(f Format) { formats[f.MediaType()] = f }
 
@@ -95,3 +159,19 @@ func Unmarshal(mediaType string, b []byte, e *event.Event) error {
 	}
 	return unknown(mediaType)
 }
+
+// MarshalBatch events to bytes using the mediaType batch format.
+func MarshalBatch(mediaType string, events []event.Event) ([]byte, error) {
+	if f := LookupBatch(mediaType); f != nil {
+		return f.MarshalBatch(events)
+	}
+	return nil, unknown(mediaType)
+}
+
+// UnmarshalBatch bytes to events using the mediaType batch format.
+func UnmarshalBatch(mediaType string, b []byte) ([]event.Event, error) {
+	if f := LookupBatch(mediaType); f != nil {
+		return f.UnmarshalBatch(b)
+	}
+	return nil, unknown(mediaType)
+}
@@ -82,3 +82,46 @@ func TestAdd(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal("undummy!", e.Data)
 }
+
+func TestJSONBatch(t *testing.T) {
+	assert := assert.New(t)
+	e := event.Event{
+		Context: event.EventContextV03{
+			Type:   "type",
+			ID:     "id",
+			Source: *types.ParseURLRef("source"),
+		}.AsV03(),
+	}
+	e.SetExtension("ex", "val")
+	assert.NoError(e.SetData("foo"))
+	b, err := format.JSONBatch.MarshalBatch([]event.Event{e, e})
+	assert.NoError(err)
+	assert.Equal(`[{"data":"foo","ex":"val","id":"id","source":"source","specversion":"0.3","type":"type"},{"data":"foo","ex":"val","id":"id","source":"source","specversion":"0.3","type":"type"}]`, string(b))
+
+	events, err := format.JSONBatch.UnmarshalBatch(b)
+	assert.NoError(err)
+	assert.Equal([]event.Event{e, e}, events)
+
+	var e2 event.Event
+	assert.EqualError(format.JSONBatch.Unmarshal(b, &e2), "expected a batch with exactly one event, found 2 events")
+
+	b, err = format.JSONBatch.Marshal(e)
+	assert.NoError(err)
+	assert.NoError(format.JSONBatch.Unmarshal(b, &e2))
+	assert.Equal(e, e2)
+
+	b, err = format.JSONBatch.MarshalBatch(nil)
+	assert.NoError(err)
+	assert.Equal(`[]`, string(b))
+}
+
+func TestLookupBatch(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(format.LookupBatch("nosuch"))
+	assert.Nil(format.LookupBatch(event.ApplicationCloudEventsJSON))
+
+	f := format.LookupBatch(event.ApplicationCloudEventsBatchJSON)
+	assert.Equal(f.MediaType(), event.ApplicationCloudEventsBatchJSON)
+	assert.Equal(format.JSONBatch, f)
+	assert.Equal(format.JSONBatch, format.Lookup(event.ApplicationCloudEventsBatchJSON))
+}
@@ -0,0 +1,16 @@
+/*
+Package protobuf implements the "application/cloudevents+protobuf" event
+format, encoding events as the CloudEvent message defined by the CloudEvents
+Protobuf Event Format specification.
+
+Importing the package registers the format with format.Add:
+
+	import _ "github.com/cloudevents/sdk-go/pkg/binding/format/protobuf"
+
+Context attributes other than id, source, specversion and type are encoded in
+the attributes map, using the CloudEventAttributeValue variant matching their
+types package type. Data is encoded as proto_data if the data content type is
+"application/protobuf", as binary_data if the event data is binary, and as
+text_data otherwise.
+*/
+package protobuf
@@ -0,0 +1,474 @@
+package protobuf
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+	"github.com/cloudevents/sdk-go/pkg/binding/spec"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/types"
+)
+
+// ContentTypeProtobuf is the data content type of events carrying a
+// protobuf message as data. Such data is encoded in the proto_data field.
+const ContentTypeProtobuf = "application/protobuf"
+
+// CloudEvent message field numbers.
+const (
+	fieldID          = 1
+	fieldSource      = 2
+	fieldSpecVersion = 3
+	fieldType        = 4
+	fieldAttributes  = 5
+	fieldBinaryData  = 6
+	fieldTextData    = 7
+	fieldProtoData   = 8
+)
+
+// CloudEventAttributeValue message field numbers.
+const (
+	fieldBoolean   = 1
+	fieldInteger   = 2
+	fieldString    = 3
+	fieldBytes     = 4
+	fieldURI       = 5
+	fieldURIRef    = 6
+	fieldTimestamp = 7
+)
+
+// Protobuf is the "application/cloudevents+protobuf" format.
+var Protobuf = protobufFmt{}
+
+func init() {
+	format.Add(Protobuf)
+}
+
+type protobufFmt struct{}
+
+func (protobufFmt) MediaType() string { return event.ApplicationCloudEventsProtobuf }
+
+func (protobufFmt) Marshal(e event.Event) ([]byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	version := spec.VS.Version(e.SpecVersion())
+	if version == nil {
+		return nil, fmt.Errorf("unknown spec version: %q", e.SpecVersion())
+	}
+
+	var enc encoder
+	enc.stringField(fieldID, e.ID())
+	enc.stringField(fieldSource, e.Source())
+	enc.stringField(fieldSpecVersion, e.SpecVersion())
+	enc.stringField(fieldType, e.Type())
+
+	attrs, err := optionalAttributes(version, e)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Deterministic output
+	for _, name := range names {
+		value := attrs[name]
+		err := enc.messageField(fieldAttributes, func(entry *encoder) error {
+			entry.stringField(1, name)
+			return entry.messageField(2, func(av *encoder) error { return encodeValue(av, value) })
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for attribute %s: %v", name, err)
+		}
+	}
+
+	if e.Data != nil {
+		data, err := e.DataBytes()
+		if err != nil {
+			return nil, err
+		}
+		if e.DeprecatedDataContentEncoding() == event.Base64 {
+			if data, err = base64.StdEncoding.DecodeString(string(data)); err != nil {
+				return nil, err
+			}
+		}
+		switch {
+		case e.DataMediaType() == ContentTypeProtobuf:
+			// google.protobuf.Any, the type URL is taken from the data schema
+			_ = enc.messageField(fieldProtoData, func(any *encoder) error {
+				if s := e.DataSchema(); s != "" {
+					any.stringField(1, s)
+				}
+				any.bytesField(2, data)
+				return nil
+			})
+		case e.DataBinary || e.DeprecatedDataContentEncoding() == event.Base64:
+			enc.bytesField(fieldBinaryData, data)
+		default:
+			enc.stringField(fieldTextData, string(data))
+		}
+	}
+	return enc.buf, nil
+}
+
+// optionalAttributes returns the optional and extension attributes of e,
+// with values of the types package types.
+func optionalAttributes(version spec.Version, e event.Event) (map[string]interface{}, error) {
+	attrs := make(map[string]interface{}, len(e.Extensions()))
+	for k, v := range e.Extensions() {
+		attrs[k] = v
+	}
+	for _, a := range version.Attributes() {
+		if a.Kind().IsRequired() {
+			continue // Encoded as CloudEvent fields
+		}
+		v := a.Get(e.Context)
+		if v == nil {
+			continue
+		}
+		switch a.Kind() {
+		case spec.Time:
+			v = types.Timestamp{Time: v.(time.Time)}
+		case spec.DataSchema:
+			u, err := url.Parse(v.(string))
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %v", a.Name(), err)
+			}
+			v = types.URI{URL: *u}
+		}
+		attrs[a.Name()] = v
+	}
+	return attrs, nil
+}
+
+// encodeValue encodes v as a CloudEventAttributeValue.
+func encodeValue(enc *encoder, v interface{}) error {
+	v, err := types.Validate(v)
+	if err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		var b uint64
+		if v {
+			b = 1
+		}
+		enc.varintField(fieldBoolean, b)
+	case int32:
+		enc.varintField(fieldInteger, uint64(int64(v)))
+	case string:
+		enc.stringField(fieldString, v)
+	case []byte:
+		enc.bytesField(fieldBytes, v)
+	case types.URI:
+		enc.stringField(fieldURI, v.String())
+	case types.URIRef:
+		enc.stringField(fieldURIRef, v.String())
+	case types.Timestamp:
+		// google.protobuf.Timestamp
+		return enc.messageField(fieldTimestamp, func(ts *encoder) error {
+			if s := v.Unix(); s != 0 {
+				ts.varintField(1, uint64(s))
+			}
+			if n := v.Nanosecond(); n != 0 {
+				ts.varintField(2, uint64(n))
+			}
+			return nil
+		})
+	default:
+		return fmt.Errorf("unsupported type %T", v)
+	}
+	return nil
+}
+
+func (protobufFmt) Unmarshal(b []byte, e *event.Event) error {
+	var (
+		id, source, specVersion, typ string
+		attrs                        = map[string]interface{}{}
+		data                         []byte
+		dataBinary, dataProto        bool
+		typeURL                      string
+	)
+
+	d := decoder{buf: b}
+	for !d.done() {
+		field, wireType, err := d.tag()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case fieldID, fieldSource, fieldSpecVersion, fieldType, fieldTextData:
+			if err := expect(field, wireType, wireBytes); err != nil {
+				return err
+			}
+			v, err := d.bytes()
+			if err != nil {
+				return err
+			}
+			switch field {
+			case fieldID:
+				id = string(v)
+			case fieldSource:
+				source = string(v)
+			case fieldSpecVersion:
+				specVersion = string(v)
+			case fieldType:
+				typ = string(v)
+			case fieldTextData:
+				data, dataBinary, dataProto = v, false, false
+			}
+		case fieldAttributes:
+			if err := expect(field, wireType, wireBytes); err != nil {
+				return err
+			}
+			entry, err := d.bytes()
+			if err != nil {
+				return err
+			}
+			name, value, err := decodeAttribute(entry)
+			if err != nil {
+				return err
+			}
+			attrs[name] = value
+		case fieldBinaryData:
+			if err := expect(field, wireType, wireBytes); err != nil {
+				return err
+			}
+			if data, err = d.bytes(); err != nil {
+				return err
+			}
+			dataBinary, dataProto = true, false
+		case fieldProtoData:
+			if err := expect(field, wireType, wireBytes); err != nil {
+				return err
+			}
+			any, err := d.bytes()
+			if err != nil {
+				return err
+			}
+			if typeURL, data, err = decodeAny(any); err != nil {
+				return err
+			}
+			dataBinary, dataProto = true, true
+		default:
+			if err := d.skip(wireType); err != nil {
+				return err
+			}
+		}
+	}
+
+	version := spec.VS.Version(specVersion)
+	if version == nil {
+		return fmt.Errorf("unknown spec version: %q", specVersion)
+	}
+	c := version.NewContext()
+	if err := c.SetID(id); err != nil {
+		return err
+	}
+	if err := c.SetSource(source); err != nil {
+		return err
+	}
+	if err := c.SetType(typ); err != nil {
+		return err
+	}
+	for name, value := range attrs {
+		if a := version.Attribute(name); a != nil {
+			// Standard attribute setters accept canonical strings
+			s, err := types.Format(value)
+			if err != nil {
+				return err
+			}
+			if err := a.Set(c, s); err != nil {
+				return err
+			}
+		} else if err := c.SetExtension(name, value); err != nil {
+			return err
+		}
+	}
+	if dataProto {
+		if c.GetDataContentType() == "" {
+			if err := c.SetDataContentType(ContentTypeProtobuf); err != nil {
+				return err
+			}
+		}
+		if c.GetDataSchema() == "" && typeURL != "" {
+			if err := c.SetDataSchema(typeURL); err != nil {
+				return err
+			}
+		}
+	}
+
+	*e = event.Event{Context: c}
+	if data != nil {
+		e.Data = data
+		e.DataEncoded = true
+		e.DataBinary = dataBinary
+	}
+	return nil
+}
+
+// decodeAttribute decodes an entry of the attributes map.
+func decodeAttribute(b []byte) (name string, value interface{}, err error) {
+	d := decoder{buf: b}
+	for !d.done() {
+		field, wireType, err := d.tag()
+		if err != nil {
+			return "", nil, err
+		}
+		switch field {
+		case 1, 2:
+			if err := expect(field, wireType, wireBytes); err != nil {
+				return "", nil, err
+			}
+			v, err := d.bytes()
+			if err != nil {
+				return "", nil, err
+			}
+			if field == 1 {
+				name = string(v)
+			} else if value, err = decodeValue(v); err != nil {
+				return "", nil, err
+			}
+		default:
+			if err := d.skip(wireType); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+	if name == "" {
+		return "", nil, fmt.Errorf("protobuf: attribute with empty name")
+	}
+	if value == nil {
+		return "", nil, fmt.Errorf("protobuf: attribute %s has no value", name)
+	}
+	return name, value, nil
+}
+
+// decodeValue decodes a CloudEventAttributeValue to a types package value.
+func decodeValue(b []byte) (value interface{}, err error) {
+	d := decoder{buf: b}
+	for !d.done() {
+		field, wireType, err := d.tag()
+		if err != nil {
+			return nil, err
+		}
+		switch field {
+		case fieldBoolean, fieldInteger:
+			if err := expect(field, wireType, wireVarint); err != nil {
+				return nil, err
+			}
+			v, err := d.varint()
+			if err != nil {
+				return nil, err
+			}
+			if field == fieldBoolean {
+				value = v != 0
+			} else {
+				value = int32(v)
+			}
+		case fieldString, fieldBytes, fieldURI, fieldURIRef, fieldTimestamp:
+			if err := expect(field, wireType, wireBytes); err != nil {
+				return nil, err
+			}
+			v, err := d.bytes()
+			if err != nil {
+				return nil, err
+			}
+			switch field {
+			case fieldString:
+				value = string(v)
+			case fieldBytes:
+				value = v
+			case fieldURI:
+				u, err := url.Parse(string(v))
+				if err != nil {
+					return nil, err
+				}
+				value = types.URI{URL: *u}
+			case fieldURIRef:
+				u, err := url.Parse(string(v))
+				if err != nil {
+					return nil, err
+				}
+				value = types.URIRef{URL: *u}
+			case fieldTimestamp:
+				if value, err = decodeTimestamp(v); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			if err := d.skip(wireType); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return value, nil
+}
+
+// decodeTimestamp decodes a google.protobuf.Timestamp.
+func decodeTimestamp(b []byte) (types.Timestamp, error) {
+	var seconds, nanos int64
+	d := decoder{buf: b}
+	for !d.done() {
+		field, wireType, err := d.tag()
+		if err != nil {
+			return types.Timestamp{}, err
+		}
+		switch field {
+		case 1, 2:
+			if err := expect(field, wireType, wireVarint); err != nil {
+				return types.Timestamp{}, err
+			}
+			v, err := d.varint()
+			if err != nil {
+				return types.Timestamp{}, err
+			}
+			if field == 1 {
+				seconds = int64(v)
+			} else {
+				nanos = int64(int32(v))
+			}
+		default:
+			if err := d.skip(wireType); err != nil {
+				return types.Timestamp{}, err
+			}
+		}
+	}
+	return types.Timestamp{Time: time.Unix(seconds, nanos).UTC()}, nil
+}
+
+// decodeAny decodes a google.protobuf.Any.
+func decodeAny(b []byte) (typeURL string, value []byte, err error) {
+	value = []byte{}
+	d := decoder{buf: b}
+	for !d.done() {
+		field, wireType, err := d.tag()
+		if err != nil {
+			return "", nil, err
+		}
+		switch field {
+		case 1, 2:
+			if err := expect(field, wireType, wireBytes); err != nil {
+				return "", nil, err
+			}
+			v, err := d.bytes()
+			if err != nil {
+				return "", nil, err
+			}
+			if field == 1 {
+				typeURL = string(v)
+			} else {
+				value = v
+			}
+		default:
+			if err := d.skip(wireType); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+	return typeURL, value, nil
+}
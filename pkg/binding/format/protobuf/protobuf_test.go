@@ -0,0 +1,99 @@
+package protobuf_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+	"github.com/cloudevents/sdk-go/pkg/binding/format/protobuf"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+func TestLookup(t *testing.T) {
+	f := format.Lookup(event.ApplicationCloudEventsProtobuf)
+	require.Equal(t, protobuf.Protobuf, f)
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	events := append(test.Events(), binaryDataEvent(), protoDataEvent())
+	for _, e := range events {
+		e := e
+		t.Run(e.ID(), func(t *testing.T) {
+			b, err := format.Marshal(event.ApplicationCloudEventsProtobuf, e)
+			require.NoError(t, err)
+
+			var have event.Event
+			require.NoError(t, format.Unmarshal(event.ApplicationCloudEventsProtobuf, b, &have))
+			test.AssertEventEquals(t, e, have)
+			require.Equal(t, e.DataBinary, have.DataBinary)
+		})
+	}
+}
+
+func TestMarshalWire(t *testing.T) {
+	e := test.MinEvent()
+	e.SetExtension("exint", -1)
+	b, err := protobuf.Protobuf.Marshal(e)
+	require.NoError(t, err)
+
+	want := []byte{
+		0x0a, 9, 'm', 'i', 'n', '-', 'e', 'v', 'e', 'n', 't',
+		0x12, 25, 'h', 't', 't', 'p', ':', '/', '/', 'e', 'x', 'a', 'm', 'p', 'l', 'e', '.', 'c', 'o', 'm', '/', 's', 'o', 'u', 'r', 'c', 'e',
+		0x1a, 3, '1', '.', '0',
+		0x22, 20, 'c', 'o', 'm', '.', 'e', 'x', 'a', 'm', 'p', 'l', 'e', '.', 'M', 'i', 'n', 'E', 'v', 'e', 'n', 't',
+		// attributes entry {"exint": {ce_integer: -1}}
+		0x2a, 20,
+		0x0a, 5, 'e', 'x', 'i', 'n', 't',
+		0x12, 11, 0x10, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01,
+	}
+	require.Equal(t, want, b)
+}
+
+func TestUnmarshalUnknownFields(t *testing.T) {
+	b, err := protobuf.Protobuf.Marshal(test.MinEvent())
+	require.NoError(t, err)
+	// Unknown varint, fixed64, bytes and fixed32 fields are skipped.
+	b = append(b,
+		0x48, 0x01,
+		0x51, 0, 0, 0, 0, 0, 0, 0, 0,
+		0x5a, 2, 'x', 'y',
+		0x65, 0, 0, 0, 0,
+	)
+
+	var have event.Event
+	require.NoError(t, protobuf.Protobuf.Unmarshal(b, &have))
+	test.AssertEventEquals(t, test.MinEvent(), have)
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	b, err := protobuf.Protobuf.Marshal(test.FullEvent())
+	require.NoError(t, err)
+
+	var e event.Event
+	require.Error(t, protobuf.Protobuf.Unmarshal(b[:len(b)-1], &e))
+	require.Error(t, protobuf.Protobuf.Unmarshal([]byte{0x1a, 3, '9', '.', '9'}, &e))
+	require.Error(t, protobuf.Protobuf.Unmarshal([]byte{0x08, 0x01}, &e))
+}
+
+func binaryDataEvent() event.Event {
+	e := test.FullEvent()
+	e.SetID("binary-data")
+	e.SetDataContentType("application/octet-stream")
+	if err := e.SetData([]byte{0, 1, 2, 3}); err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func protoDataEvent() event.Event {
+	e := test.MinEvent()
+	e.SetID("proto-data")
+	e.SetDataContentType(protobuf.ContentTypeProtobuf)
+	e.SetDataSchema(test.Schema.String())
+	if err := e.SetData([]byte{0x08, 0x96, 0x01}); err != nil {
+		panic(err)
+	}
+	return e
+}
@@ -0,0 +1,134 @@
+package protobuf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Protobuf wire types used by the CloudEvent message.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("protobuf: truncated message")
+
+// encoder appends protobuf wire-format fields to a byte slice.
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	e.buf = append(e.buf, b[:n]...)
+}
+
+func (e *encoder) tag(field int, wireType int) {
+	e.varint(uint64(field)<<3 | uint64(wireType))
+}
+
+func (e *encoder) varintField(field int, v uint64) {
+	e.tag(field, wireVarint)
+	e.varint(v)
+}
+
+func (e *encoder) bytesField(field int, b []byte) {
+	e.tag(field, wireBytes)
+	e.varint(uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *encoder) stringField(field int, s string) {
+	e.tag(field, wireBytes)
+	e.varint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+// messageField encodes a nested message produced by fn.
+func (e *encoder) messageField(field int, fn func(*encoder) error) error {
+	var nested encoder
+	if err := fn(&nested); err != nil {
+		return err
+	}
+	e.bytesField(field, nested.buf)
+	return nil
+}
+
+// decoder reads protobuf wire-format fields from a byte slice.
+type decoder struct {
+	buf []byte
+}
+
+func (d *decoder) done() bool { return len(d.buf) == 0 }
+
+func (d *decoder) varint() (uint64, error) {
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		return 0, errTruncated
+	}
+	d.buf = d.buf[n:]
+	return v, nil
+}
+
+func (d *decoder) tag() (field int, wireType int, err error) {
+	v, err := d.varint()
+	if err != nil {
+		return 0, 0, err
+	}
+	if v>>3 == 0 || v>>3 > math.MaxInt32 {
+		return 0, 0, fmt.Errorf("protobuf: invalid field number %d", v>>3)
+	}
+	return int(v >> 3), int(v & 7), nil
+}
+
+func (d *decoder) bytes() ([]byte, error) {
+	l, err := d.varint()
+	if err != nil {
+		return nil, err
+	}
+	if l > uint64(len(d.buf)) {
+		return nil, errTruncated
+	}
+	b := d.buf[:l:l]
+	d.buf = d.buf[l:]
+	return b, nil
+}
+
+// skip discards the value of an unknown field.
+func (d *decoder) skip(wireType int) error {
+	switch wireType {
+	case wireVarint:
+		_, err := d.varint()
+		return err
+	case wireFixed64:
+		return d.advance(8)
+	case wireBytes:
+		_, err := d.bytes()
+		return err
+	case wireFixed32:
+		return d.advance(4)
+	default:
+		return fmt.Errorf("protobuf: unsupported wire type %d", wireType)
+	}
+}
+
+func (d *decoder) advance(n int) error {
+	if n > len(d.buf) {
+		return errTruncated
+	}
+	d.buf = d.buf[n:]
+	return nil
+}
+
+// expect returns an error if wireType doesn't match the wire type of field.
+func expect(field int, wireType int, want int) error {
+	if wireType != want {
+		return fmt.Errorf("protobuf: field %d has wire type %d, expected %d", field, wireType, want)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package signature
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/cloudevents/sdk-go/pkg/binding/spec"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/types"
+)
+
+// Canonicalize returns the bytes covered by the signature of the event.
+//
+// The attributes and extensions, except the signature extensions, are sorted by name and each one is
+// written as the length prefixed name followed by the length prefixed canonical string of the value,
+// then the data bytes are appended. Lengths are unsigned varints.
+// Using canonical strings makes the signature independent of how the transports encode the values.
+func Canonicalize(e event.Event) ([]byte, error) {
+	version := spec.VS.Version(e.SpecVersion())
+	if version == nil {
+		return nil, fmt.Errorf("invalid spec version %q", e.SpecVersion())
+	}
+	values := make(map[string]interface{})
+	for _, a := range version.Attributes() {
+		if v := a.Get(e.Context); v != nil {
+			values[a.Name()] = v
+		}
+	}
+	for name, v := range e.Extensions() {
+		if name != SignatureExtension && name != SignatureKeyIDExtension {
+			values[name] = v
+		}
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	for _, name := range names {
+		s, err := types.Format(values[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %v", name, err)
+		}
+		if s == "" {
+			continue
+		}
+		writeBytes(&buf, []byte(name))
+		writeBytes(&buf, []byte(s))
+	}
+	data, err := e.DataBytes()
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(data)
+	return buf.Bytes(), nil
+}
+
+func writeBytes(buf *bytes.Buffer, b []byte) {
+	var n [binary.MaxVarintLen64]byte
+	buf.Write(n[:binary.PutUvarint(n[:], uint64(len(b)))])
+	buf.Write(b)
+}
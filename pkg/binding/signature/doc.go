@@ -0,0 +1,17 @@
+/*
+Package signature signs and verifies events, storing the signature in the signature extensions.
+
+The signature covers the canonical form of the event, see Canonicalize: all the attributes and
+extensions, except the signature extensions, and the data. The extension signaturekeyid identifies
+the key, so that receivers can verify events signed with different keys during a key rotation:
+
+	signer := signature.NewEd25519Signer("key-2020", privateKey)
+	sender, err := kafka_sarama.NewSender(client, topic, kafka_sarama.WithTransformer(signature.Signing(signer)))
+
+	keys := signature.Keys{
+		"key-2019": signature.NewEd25519Verifier(oldPublicKey),
+		"key-2020": signature.NewEd25519Verifier(publicKey),
+	}
+	c, err := client.New(t, client.WithEventVerifier(keys.VerifyEvent))
+*/
+package signature
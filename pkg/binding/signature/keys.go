@@ -0,0 +1,128 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"math/big"
+)
+
+// Signer signs the canonical form of the events.
+type Signer interface {
+	// KeyID identifies the key used to sign.
+	KeyID() string
+	// Sign returns the signature of data.
+	Sign(data []byte) ([]byte, error)
+}
+
+// Verifier verifies the signatures made with a key.
+type Verifier interface {
+	// Verify returns ErrInvalidSignature if signature is not a valid signature of data.
+	Verify(data, signature []byte) error
+}
+
+// NewHMACSigner returns a Signer computing HMAC-SHA256 signatures with secret.
+func NewHMACSigner(keyID string, secret []byte) Signer {
+	return hmacKey{keyID: keyID, secret: secret}
+}
+
+// NewHMACVerifier returns a Verifier of the HMAC-SHA256 signatures computed with secret.
+func NewHMACVerifier(secret []byte) Verifier {
+	return hmacKey{secret: secret}
+}
+
+type hmacKey struct {
+	keyID  string
+	secret []byte
+}
+
+func (k hmacKey) KeyID() string { return k.keyID }
+
+func (k hmacKey) Sign(data []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, k.secret)
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
+
+func (k hmacKey) Verify(data, signature []byte) error {
+	expected, _ := k.Sign(data)
+	if !hmac.Equal(expected, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// NewEd25519Signer returns a Signer computing Ed25519 signatures with key.
+func NewEd25519Signer(keyID string, key ed25519.PrivateKey) Signer {
+	return ed25519Signer{keyID: keyID, key: key}
+}
+
+// NewEd25519Verifier returns a Verifier of the Ed25519 signatures made with the private key of key.
+func NewEd25519Verifier(key ed25519.PublicKey) Verifier {
+	return ed25519Verifier{key: key}
+}
+
+type ed25519Signer struct {
+	keyID string
+	key   ed25519.PrivateKey
+}
+
+func (k ed25519Signer) KeyID() string { return k.keyID }
+
+func (k ed25519Signer) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(k.key, data), nil
+}
+
+type ed25519Verifier struct {
+	key ed25519.PublicKey
+}
+
+func (k ed25519Verifier) Verify(data, signature []byte) error {
+	if !ed25519.Verify(k.key, data, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// NewECDSASigner returns a Signer computing ECDSA signatures of the SHA-256 digest with key,
+// encoded in ASN.1 DER.
+func NewECDSASigner(keyID string, key *ecdsa.PrivateKey) Signer {
+	return ecdsaSigner{keyID: keyID, key: key}
+}
+
+// NewECDSAVerifier returns a Verifier of the ECDSA signatures made with the private key of key.
+func NewECDSAVerifier(key *ecdsa.PublicKey) Verifier {
+	return ecdsaVerifier{key: key}
+}
+
+type ecdsaSigner struct {
+	keyID string
+	key   *ecdsa.PrivateKey
+}
+
+func (k ecdsaSigner) KeyID() string { return k.keyID }
+
+func (k ecdsaSigner) Sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+	return k.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+type ecdsaVerifier struct {
+	key *ecdsa.PublicKey
+}
+
+func (k ecdsaVerifier) Verify(data, signature []byte) error {
+	var sig struct{ R, S *big.Int }
+	if rest, err := asn1.Unmarshal(signature, &sig); err != nil || len(rest) > 0 {
+		return ErrInvalidSignature
+	}
+	digest := sha256.Sum256(data)
+	if !ecdsa.Verify(k.key, digest[:], sig.R, sig.S) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
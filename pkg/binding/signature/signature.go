@@ -0,0 +1,100 @@
+package signature
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/types"
+)
+
+const (
+	// SignatureExtension holds the base64 encoded signature of the event
+	SignatureExtension = "signature"
+	// SignatureKeyIDExtension holds the ID of the key used to sign the event
+	SignatureKeyIDExtension = "signaturekeyid"
+)
+
+var (
+	// ErrMissingSignature is returned when verifying an event without signature
+	ErrMissingSignature = errors.New("missing event signature")
+	// ErrInvalidSignature is returned when verifying an event with an invalid signature
+	ErrInvalidSignature = errors.New("invalid event signature")
+	// ErrUnknownKey is returned when verifying an event signed with an unknown key
+	ErrUnknownKey = errors.New("unknown event signature key")
+)
+
+// Sign signs the event with signer, setting the signature extensions.
+func Sign(e *event.Event, signer Signer) error {
+	data, err := Canonicalize(*e)
+	if err != nil {
+		return err
+	}
+	sig, err := signer.Sign(data)
+	if err != nil {
+		return err
+	}
+	if err := e.Context.SetExtension(SignatureExtension, base64.StdEncoding.EncodeToString(sig)); err != nil {
+		return err
+	}
+	return e.Context.SetExtension(SignatureKeyIDExtension, signer.KeyID())
+}
+
+// Keys maps the key IDs to the Verifiers of their signatures.
+type Keys map[string]Verifier
+
+// Verify returns an error if the event has a missing or invalid signature,
+// or it's signed with a key not in keys.
+func (k Keys) Verify(e event.Event) error {
+	sigValue, ok := e.Extensions()[SignatureExtension]
+	if !ok {
+		return ErrMissingSignature
+	}
+	sigStr, err := types.ToString(sigValue)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	sig, err := base64.StdEncoding.DecodeString(sigStr)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	var keyID string
+	if v, ok := e.Extensions()[SignatureKeyIDExtension]; ok {
+		keyID, _ = types.ToString(v)
+	}
+	verifier, ok := k[keyID]
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownKey, keyID)
+	}
+
+	data, err := Canonicalize(e)
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(data, sig)
+}
+
+// VerifyEvent verifies the event like Verify, use it with client.WithEventVerifier.
+func (k Keys) VerifyEvent(_ context.Context, e event.Event) error {
+	return k.Verify(e)
+}
+
+// Signing returns a transformer which signs the events with signer.
+// The messages are converted to events while encoding.
+func Signing(signer Signer) binding.TransformerFactory {
+	return binding.EventTransformerFactory(func(e *event.Event) error {
+		return Sign(e, signer)
+	})
+}
+
+// Verifying returns a transformer which fails if the events have a missing or invalid signature,
+// or they are signed with a key not in keys.
+func Verifying(keys Keys) binding.TransformerFactory {
+	return binding.EventTransformerFactory(func(e *event.Event) error {
+		return keys.Verify(*e)
+	})
+}
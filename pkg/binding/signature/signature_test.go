@@ -0,0 +1,125 @@
+package signature_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/signature"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+func keyPairs(t *testing.T) map[string]struct {
+	signer   signature.Signer
+	verifier signature.Verifier
+} {
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	secret := []byte("secret")
+
+	return map[string]struct {
+		signer   signature.Signer
+		verifier signature.Verifier
+	}{
+		"hmac":    {signature.NewHMACSigner("hmac", secret), signature.NewHMACVerifier(secret)},
+		"ed25519": {signature.NewEd25519Signer("ed25519", edPriv), signature.NewEd25519Verifier(edPub)},
+		"ecdsa":   {signature.NewECDSASigner("ecdsa", ecPriv), signature.NewECDSAVerifier(&ecPriv.PublicKey)},
+	}
+}
+
+func clone(e event.Event) event.Event {
+	e.Context = e.Context.Clone()
+	return e
+}
+
+func TestSignAndVerify(t *testing.T) {
+	for name, kp := range keyPairs(t) {
+		t.Run(name, func(t *testing.T) {
+			keys := signature.Keys{name: kp.verifier}
+			e := test.FullEvent()
+			require.Equal(t, signature.ErrMissingSignature, keys.Verify(e))
+
+			require.NoError(t, signature.Sign(&e, kp.signer))
+			require.Equal(t, name, e.Extensions()[signature.SignatureKeyIDExtension])
+			require.NoError(t, keys.Verify(e))
+
+			// The signature survives the encoding in binary and structured messages
+			for _, m := range []binding.Message{test.MustCreateMockBinaryMessage(e), test.MustCreateMockStructuredMessage(e)} {
+				received, err := binding.ToEvent(context.TODO(), m, binding.TransformerFactories{signature.Verifying(keys)})
+				require.NoError(t, err)
+				require.NoError(t, keys.Verify(*received))
+			}
+
+			tampered := clone(e)
+			tampered.SetSubject("tampered")
+			require.Equal(t, signature.ErrInvalidSignature, keys.Verify(tampered))
+
+			tampered = clone(e)
+			require.NoError(t, tampered.SetData("tampered"))
+			require.Equal(t, signature.ErrInvalidSignature, keys.Verify(tampered))
+
+			_, err := binding.ToEvent(context.TODO(), binding.EventMessage(tampered), binding.TransformerFactories{signature.Verifying(keys)})
+			require.Equal(t, signature.ErrInvalidSignature, err)
+
+			require.True(t, errors.Is(signature.Keys{}.Verify(e), signature.ErrUnknownKey))
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKey := signature.NewHMACSigner("old", []byte("old secret"))
+	newKey := signature.NewHMACSigner("new", []byte("new secret"))
+	keys := signature.Keys{
+		"old": signature.NewHMACVerifier([]byte("old secret")),
+		"new": signature.NewHMACVerifier([]byte("new secret")),
+	}
+
+	for _, signer := range []signature.Signer{oldKey, newKey} {
+		e, err := binding.ToEvent(context.TODO(), binding.EventMessage(test.FullEvent()), binding.TransformerFactories{signature.Signing(signer)})
+		require.NoError(t, err)
+		require.NoError(t, keys.VerifyEvent(context.TODO(), *e))
+	}
+
+	// A signature made with a key, pretending to be made with another key
+	e := test.FullEvent()
+	require.NoError(t, signature.Sign(&e, oldKey))
+	e.SetExtension(signature.SignatureKeyIDExtension, "new")
+	require.Equal(t, signature.ErrInvalidSignature, keys.Verify(e))
+}
+
+func TestCanonicalize(t *testing.T) {
+	e := test.FullEvent()
+	canonical, err := signature.Canonicalize(e)
+	require.NoError(t, err)
+
+	// Extensions converted to strings, like in binary messages, have the same canonical form
+	converted := test.ExToStr(t, e)
+	convertedCanonical, err := signature.Canonicalize(converted)
+	require.NoError(t, err)
+	require.Equal(t, canonical, convertedCanonical)
+
+	// Signature extensions are ignored
+	require.NoError(t, signature.Sign(&e, signature.NewHMACSigner("key", []byte("secret"))))
+	signedCanonical, err := signature.Canonicalize(e)
+	require.NoError(t, err)
+	require.Equal(t, canonical, signedCanonical)
+
+	other := test.FullEvent()
+	other.SetExtension("other", "x")
+	otherCanonical, err := signature.Canonicalize(other)
+	require.NoError(t, err)
+	require.NotEqual(t, canonical, otherCanonical)
+
+	_, err = signature.Canonicalize(event.Event{Context: &event.EventContextV1{}})
+	require.NoError(t, err)
+}
@@ -15,12 +15,18 @@ import (
 // Generic error when a conversion of a Message to an Event fails
 var ErrCannotConvertToEvent = errors.New("cannot convert message to event")
 
+// Error returned by ToEvent when the message contains a batch of events
+var ErrCannotConvertBatchToEvent = errors.New("cannot convert batch message to a single event, use ToEvents")
+
 // Translates a Message with a valid Structured or Binary representation to an Event.
 // This function returns the Event generated from the Message and the original encoding of the message or
 // an error that points the conversion error.
 // transformers can be nil and this function guarantees that they are invoked only once during the encoding process.
 func ToEvent(ctx context.Context, message MessageReader, transformers TransformerFactories) (*event.Event, error) {
 	messageEncoding := message.ReadEncoding()
+	if messageEncoding == EncodingBatch {
+		return nil, ErrCannotConvertBatchToEvent
+	}
 	if messageEncoding == EncodingEvent {
 		for m := message; m != nil; {
 			if em, ok := m.(EventMessage); ok {
@@ -56,6 +62,61 @@ func ToEvent(ctx context.Context, message MessageReader, transformers Transforme
 	return &e, nil
 }
 
+// Translates a Message to a slice of Events.
+// If the Message is in batch encoding, this function returns all the events contained in the batch,
+// otherwise it returns a slice containing the single Event generated by ToEvent.
+// transformers can be nil and this function guarantees that they are invoked only once for each event.
+func ToEvents(ctx context.Context, message MessageReader, transformers TransformerFactories) ([]event.Event, error) {
+	if message.ReadEncoding() != EncodingBatch {
+		e, err := ToEvent(ctx, message, transformers)
+		if err != nil {
+			return nil, err
+		}
+		return []event.Event{*e}, nil
+	}
+
+	br := batchReaderOf(message)
+	if br == nil {
+		return nil, ErrCannotConvertToEvent
+	}
+
+	var events []event.Event
+	if em, ok := br.(EventBatchMessage); ok {
+		events = make([]event.Event, len(em))
+		copy(events, em)
+	} else {
+		builder := &messageToEventsBuilder{}
+		if err := br.ReadBatch(ctx, builder); err != nil {
+			return nil, err
+		}
+		events = builder.events
+	}
+
+	transformer := transformers.EventTransformer()
+	for i := range events {
+		if err := transformer(&events[i]); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+type messageToEventsBuilder struct {
+	events []event.Event
+}
+
+var _ BatchWriter = (*messageToEventsBuilder)(nil)
+
+func (b *messageToEventsBuilder) SetBatchEvents(ctx context.Context, format format.BatchFormat, events io.Reader) error {
+	var buf bytes.Buffer
+	_, err := io.Copy(&buf, events)
+	if err != nil {
+		return err
+	}
+	b.events, err = format.UnmarshalBatch(buf.Bytes())
+	return err
+}
+
 type messageToEventBuilder struct {
 	event *event.Event
 }
@@ -77,3 +77,19 @@ func (t TransformerFactories) EventTransformer() EventTransformer {
 
 // EventTransformer mutates the provided Event
 type EventTransformer func(*event.Event) error
+
+// EventTransformerFactory adapts an EventTransformer to a TransformerFactory transforming only events:
+// the messages are converted to events while encoding.
+type EventTransformerFactory EventTransformer
+
+func (f EventTransformerFactory) StructuredTransformer(StructuredWriter) StructuredWriter {
+	return nil
+}
+
+func (f EventTransformerFactory) BinaryTransformer(BinaryWriter) BinaryWriter {
+	return nil
+}
+
+func (f EventTransformerFactory) EventTransformer() EventTransformer {
+	return EventTransformer(f)
+}
@@ -66,6 +66,7 @@ func DirectWrite(
 // Returns:
 // * EncodingStructured, nil if message is correctly encoded in structured encoding
 // * EncodingBinary, nil if message is correctly encoded in binary encoding
+// * EncodingBatch, nil if message is a batch and it's correctly encoded using the structuredWriter, which implements BatchWriter
 // * EncodingUnknown, ErrUnknownEncoding if message.ReadEncoding() == EncodingUnknown
 // * _, err if error happened during the encoding
 func Write(
@@ -77,6 +78,12 @@ func Write(
 ) (Encoding, error) {
 	enc := message.ReadEncoding()
 	var err error
+	// Batches can be written only by protocols supporting them
+	if enc == EncodingBatch {
+		if batchWriter, ok := structuredWriter.(BatchWriter); ok {
+			return EncodingBatch, WriteBatch(ctx, message, batchWriter, transformers)
+		}
+	}
 	// Skip direct encoding if the event is an event message
 	if enc != EncodingEvent {
 		enc, err = DirectWrite(ctx, message, structuredWriter, binaryWriter, transformers)
@@ -113,6 +120,30 @@ func Write(
 	return EncodingUnknown, ErrUnknownEncoding
 }
 
+// Writes the message to the provided BatchWriter:
+// 1. If the message is a batch and no transformers are provided, the batch is directly written to the BatchWriter
+// 2. Otherwise, it uses ToEvents to generate the Event representations, applying the transformers
+// 3. The Events are encoded back as a batch to the BatchWriter
+// A Message which is not a batch is written as a batch containing a single event.
+func WriteBatch(
+	ctx context.Context,
+	message MessageReader,
+	batchWriter BatchWriter,
+	transformers TransformerFactories,
+) error {
+	if len(transformers) == 0 && message.ReadEncoding() == EncodingBatch {
+		if br := batchReaderOf(message); br != nil {
+			return br.ReadBatch(ctx, batchWriter)
+		}
+	}
+
+	events, err := ToEvents(ctx, message, transformers)
+	if err != nil {
+		return err
+	}
+	return EventBatchMessage(events).ReadBatch(ctx, batchWriter)
+}
+
 // Skip direct structured to structured encoding during the encoding process
 func WithSkipDirectStructuredEncoding(ctx context.Context, skip bool) context.Context {
 	return context.WithValue(ctx, SKIP_DIRECT_STRUCTURED_ENCODING, skip)
@@ -0,0 +1,246 @@
+package cesql
+
+import (
+	"math"
+	"regexp"
+)
+
+// node is a type checked expression node.
+type node interface {
+	// Type is the static type of the values returned by eval.
+	Type() Type
+	// eval always returns a value, adding the raised errors to errs.
+	eval(a Attributes, errs *Errors) interface{}
+}
+
+type literal struct{ value interface{} }
+
+func (n literal) Type() Type                           { return typeOf(n.value) }
+func (n literal) eval(Attributes, *Errors) interface{} { return n.value }
+
+type attribute struct{ name string }
+
+// missing is the value of a missing attribute. The nodes evaluating it return their zero value,
+// without casting it, since the whole expression returns its zero value.
+type missing struct{}
+
+func isMissing(v interface{}) bool {
+	_, ok := v.(missing)
+	return ok
+}
+
+func (n attribute) Type() Type { return Any }
+
+func (n attribute) eval(a Attributes, errs *Errors) interface{} {
+	v := a.Get(n.name)
+	if v == nil {
+		errs.add(MissingAttributeError, "missing attribute %q", n.name)
+		return missing{}
+	}
+	value, err := attributeValue(v)
+	if err != nil {
+		errs.add(CastError, "invalid value for attribute %q: %v", n.name, err)
+		return ""
+	}
+	return value
+}
+
+type exists struct{ name string }
+
+func (n exists) Type() Type { return Boolean }
+
+func (n exists) eval(a Attributes, _ *Errors) interface{} { return a.Get(n.name) != nil }
+
+// operand evaluates n and casts the value to t. A missing attribute is the zero value of t.
+func operand(n node, t Type, a Attributes, errs *Errors) interface{} {
+	v := n.eval(a, errs)
+	if isMissing(v) {
+		return zero(t)
+	}
+	return cast(v, t, errs)
+}
+
+type not struct{ x node }
+
+func (n not) Type() Type { return Boolean }
+
+func (n not) eval(a Attributes, errs *Errors) interface{} {
+	return !operand(n.x, Boolean, a, errs).(bool)
+}
+
+type negate struct{ x node }
+
+func (n negate) Type() Type { return Integer }
+
+func (n negate) eval(a Attributes, errs *Errors) interface{} {
+	x := operand(n.x, Integer, a, errs).(int32)
+	if x == math.MinInt32 {
+		errs.add(MathError, "-(%d) overflows", x)
+		return int32(math.MaxInt32)
+	}
+	return -x
+}
+
+// logical is a short-circuit AND, OR or a XOR.
+type logical struct {
+	op   string
+	l, r node
+}
+
+func (n logical) Type() Type { return Boolean }
+
+func (n logical) eval(a Attributes, errs *Errors) interface{} {
+	l := operand(n.l, Boolean, a, errs).(bool)
+	switch {
+	case n.op == "AND" && !l:
+		return false
+	case n.op == "OR" && l:
+		return true
+	}
+	r := operand(n.r, Boolean, a, errs).(bool)
+	if n.op == "XOR" {
+		return l != r
+	}
+	return r
+}
+
+type arithmetic struct {
+	op   string
+	l, r node
+}
+
+func (n arithmetic) Type() Type { return Integer }
+
+func (n arithmetic) eval(a Attributes, errs *Errors) interface{} {
+	l := operand(n.l, Integer, a, errs).(int32)
+	r := operand(n.r, Integer, a, errs).(int32)
+	switch n.op {
+	case "+":
+		return n.checked(l, r, int64(l)+int64(r), errs)
+	case "-":
+		return n.checked(l, r, int64(l)-int64(r), errs)
+	case "*":
+		return n.checked(l, r, int64(l)*int64(r), errs)
+	}
+	if r == 0 {
+		errs.add(MathError, "division by zero")
+		return int32(0)
+	}
+	if l == math.MinInt32 && r == -1 {
+		if n.op == "%" {
+			return int32(0)
+		}
+		return n.checked(l, r, -int64(l), errs)
+	}
+	if n.op == "/" {
+		return l / r
+	}
+	return l % r
+}
+
+// checked returns the result v of l op r, or the nearest Integer and a MathError if v overflows.
+func (n arithmetic) checked(l, r int32, v int64, errs *Errors) int32 {
+	switch {
+	case v > math.MaxInt32:
+		errs.add(MathError, "%d %s %d overflows", l, n.op, r)
+		return math.MaxInt32
+	case v < math.MinInt32:
+		errs.add(MathError, "%d %s %d overflows", l, n.op, r)
+		return math.MinInt32
+	}
+	return int32(v)
+}
+
+// compare is an Integer comparison.
+type compare struct {
+	op   string
+	l, r node
+}
+
+func (n compare) Type() Type { return Boolean }
+
+func (n compare) eval(a Attributes, errs *Errors) interface{} {
+	l := operand(n.l, Integer, a, errs).(int32)
+	r := operand(n.r, Integer, a, errs).(int32)
+	switch n.op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	default:
+		return l >= r
+	}
+}
+
+// equal compares values of any type, the right value is cast to the type of the left value.
+type equal struct {
+	negate bool
+	l, r   node
+}
+
+func (n equal) Type() Type { return Boolean }
+
+func (n equal) eval(a Attributes, errs *Errors) interface{} {
+	l := n.l.eval(a, errs)
+	if isMissing(l) {
+		return false
+	}
+	r := operand(n.r, typeOf(l), a, errs)
+	return (l == r) != n.negate
+}
+
+type like struct {
+	negate  bool
+	x       node
+	pattern *regexp.Regexp
+}
+
+func (n like) Type() Type { return Boolean }
+
+func (n like) eval(a Attributes, errs *Errors) interface{} {
+	return n.pattern.MatchString(operand(n.x, String, a, errs).(string)) != n.negate
+}
+
+// in evaluates all the set values, casting them to the type of x.
+type in struct {
+	negate bool
+	x      node
+	set    []node
+}
+
+func (n in) Type() Type { return Boolean }
+
+func (n in) eval(a Attributes, errs *Errors) interface{} {
+	x := n.x.eval(a, errs)
+	if isMissing(x) {
+		return false
+	}
+	found := false
+	for _, e := range n.set {
+		if operand(e, typeOf(x), a, errs) == x {
+			found = true
+		}
+	}
+	return found != n.negate
+}
+
+type call struct {
+	fn   *function
+	args []node
+}
+
+func (n call) Type() Type { return n.fn.returns }
+
+func (n call) eval(a Attributes, errs *Errors) interface{} {
+	args := make([]interface{}, len(n.args))
+	for i, arg := range n.args {
+		args[i] = operand(arg, n.fn.param(i), a, errs)
+	}
+	v, err := n.fn.impl(args)
+	if err != nil {
+		*errs = append(*errs, err)
+	}
+	return v
+}
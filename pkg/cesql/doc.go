@@ -0,0 +1,25 @@
+/*
+Package cesql implements CloudEvents SQL (CESQL), the expression language defined by the
+CloudEvents SQL Expression Language specification, to evaluate expressions like:
+
+	type LIKE 'com.acme.%' AND EXISTS subject
+
+Parse parses and type checks an expression, returning an Expression that can be evaluated
+any number of times against an event.EventContextReader, or against any other source of
+attributes through the Attributes interface.
+
+Attribute values are mapped to the CESQL types: Boolean and Integer attributes keep their
+type, all the other attributes (String, Binary, URI, URI-reference and Timestamp) are
+Strings in their canonical string representation, see the types package.
+Operands and function arguments are implicitly cast to the expected types.
+
+Evaluation is total: it always returns a value, together with the errors raised
+during the evaluation, if any. For example a division by zero returns 0 and a MathError,
+and an Integer overflow returns the nearest Integer and a MathError.
+When an attribute that is not present in the event is evaluated, the whole expression returns
+the zero value of its type and a MissingAttributeError: missing = 1 returns false, like
+NOT (missing = 1). AND and OR don't evaluate their right operand when the left one decides
+the result, so TRUE OR missing = 1 returns true without errors, while
+(missing = 1) OR TRUE returns false and a MissingAttributeError.
+*/
+package cesql
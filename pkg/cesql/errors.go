@@ -0,0 +1,74 @@
+package cesql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorKind classifies CESQL errors, as defined by the specification.
+type ErrorKind int
+
+const (
+	// ParseError is raised when an expression can't be parsed.
+	ParseError ErrorKind = iota
+	// MathError is raised by math operations, like a division by zero.
+	MathError
+	// CastError is raised when a value can't be cast to the expected type.
+	CastError
+	// MissingAttributeError is raised when an expression references an attribute
+	// not present in the event.
+	MissingAttributeError
+	// MissingFunctionError is raised when an expression invokes an unknown function,
+	// or a function with the wrong number of arguments.
+	MissingFunctionError
+	// FunctionEvaluationError is raised by a function for invalid arguments.
+	FunctionEvaluationError
+)
+
+var errorKindNames = []string{
+	"parse error",
+	"math error",
+	"cast error",
+	"missing attribute error",
+	"missing function error",
+	"function evaluation error",
+}
+
+func (k ErrorKind) String() string { return errorKindNames[k] }
+
+// Error is an error raised while parsing or evaluating an expression.
+type Error struct {
+	Kind    ErrorKind
+	Message string
+}
+
+func (e *Error) Error() string { return e.Kind.String() + ": " + e.Message }
+
+func newError(kind ErrorKind, format string, args ...interface{}) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+// Errors is the list of errors raised while evaluating an expression.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+func (e *Errors) add(kind ErrorKind, format string, args ...interface{}) {
+	*e = append(*e, newError(kind, format, args...))
+}
+
+// has reports if an error of kind was raised
+func (e Errors) has(kind ErrorKind) bool {
+	for _, err := range e {
+		if err.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package cesql
+
+import (
+	"github.com/cloudevents/sdk-go/pkg/binding/spec"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+// Attributes gives access to the context attributes of an event by name.
+type Attributes interface {
+	// Get returns the value of the named attribute or extension, or nil if it is not set.
+	// Names are lower case.
+	Get(name string) interface{}
+}
+
+// Expression is a parsed and type checked CESQL expression.
+type Expression interface {
+	// Type is the type of the values returned by the evaluation, or Any if it can be
+	// known only during the evaluation, like for an expression referencing a single attribute.
+	Type() Type
+
+	// Evaluate the expression against an event context.
+	// It returns a bool, an int32 or a string value, and the Errors raised during the
+	// evaluation if any. When a missing attribute is evaluated the value is the zero value of Type,
+	// false for Any, so a comparison with a missing attribute never evaluates to true.
+	// The right operand of AND and OR isn't evaluated when the left one decides the result.
+	Evaluate(event.EventContextReader) (interface{}, error)
+
+	// EvaluateAttributes evaluates the expression like Evaluate, reading attributes from a.
+	EvaluateAttributes(a Attributes) (interface{}, error)
+
+	// String returns the source of the expression.
+	String() string
+}
+
+// Parse parses and type checks a CESQL expression.
+// It returns an *Error if the expression is invalid, like for a syntax error, a call to
+// an unknown function or an operand that can never be cast to the expected type.
+func Parse(expression string) (Expression, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, p.unexpected("expected end of expression")
+	}
+	return &parsedExpression{source: expression, root: root}, nil
+}
+
+type parsedExpression struct {
+	source string
+	root   node
+}
+
+func (e *parsedExpression) Type() Type { return e.root.Type() }
+
+func (e *parsedExpression) String() string { return e.source }
+
+func (e *parsedExpression) Evaluate(c event.EventContextReader) (interface{}, error) {
+	return e.EvaluateAttributes(ContextAttributes(c))
+}
+
+func (e *parsedExpression) EvaluateAttributes(a Attributes) (interface{}, error) {
+	var errs Errors
+	v := e.root.eval(a, &errs)
+	if len(errs) == 0 {
+		return v, nil
+	}
+	if errs.has(MissingAttributeError) {
+		t := e.Type()
+		if t == Any {
+			t = Boolean
+		}
+		v = zero(t)
+	}
+	return v, errs
+}
+
+// ContextAttributes returns the Attributes of an event context.
+func ContextAttributes(c event.EventContextReader) Attributes {
+	return contextAttributes{c: c, version: spec.VS.Version(c.GetSpecVersion())}
+}
+
+// contextAttributes reads attributes from an event context.
+type contextAttributes struct {
+	c       event.EventContextReader
+	version spec.Version
+}
+
+func (a contextAttributes) Get(name string) interface{} {
+	if a.version != nil {
+		if attr := a.version.Attribute(name); attr != nil {
+			return attr.Get(a.c)
+		}
+	}
+	if v, ok := a.c.GetExtensions()[name]; ok {
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package cesql_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/cesql"
+)
+
+type evaluation struct {
+	want       interface{}
+	wantErrors []cesql.ErrorKind
+}
+
+func ok(v interface{}) evaluation { return evaluation{want: v} }
+
+func fails(v interface{}, kinds ...cesql.ErrorKind) evaluation {
+	return evaluation{want: v, wantErrors: kinds}
+}
+
+func assertEvaluations(t *testing.T, tests map[string]evaluation) {
+	e := test.FullEvent()
+	for expression, tt := range tests {
+		t.Run(expression, func(t *testing.T) {
+			expr, err := cesql.Parse(expression)
+			require.NoError(t, err)
+			require.Equal(t, expression, expr.String())
+
+			got, err := expr.Evaluate(e.Context)
+			require.Equal(t, tt.want, got)
+			if tt.wantErrors == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.IsType(t, cesql.Errors{}, err)
+			var kinds []cesql.ErrorKind
+			for _, e := range err.(cesql.Errors) {
+				kinds = append(kinds, e.Kind)
+			}
+			require.Equal(t, tt.wantErrors, kinds)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	assertEvaluations(t, map[string]evaluation{
+		// Literals
+		"TRUE":          ok(true),
+		"false":         ok(false),
+		"42":            ok(int32(42)),
+		"-2147483648":   ok(int32(-2147483648)),
+		"'a \\'b\\' c'": ok("a 'b' c"),
+		`"a \"b\" c"`:   ok(`a "b" c`),
+
+		// Attributes
+		"type":        ok("com.example.FullEvent"),
+		"specversion": ok("1.0"),
+		"time":        ok("2020-03-21T12:34:56.78Z"),
+		"dataschema":  ok("http://example.com/schema"),
+		"exbool":      ok(true),
+		"exint":       ok(int32(42)),
+		"exbinary":    ok("AAECAw=="),
+		"exurl":       ok("http://example.com/source"),
+		"TYPE":        ok("com.example.FullEvent"),
+		"nosuch":      fails(false, cesql.MissingAttributeError),
+
+		// Comparisons
+		"type = 'com.example.FullEvent'":  ok(true),
+		"type != 'com.example.FullEvent'": ok(false),
+		"type <> 'x'":                     ok(true),
+		"exint = 42":                      ok(true),
+		"exint = '42'":                    ok(true),
+		"'42' = exint":                    ok(true),
+		"exint = 'x'":                     fails(false, cesql.CastError),
+		"exbool = 'TRUE'":                 ok(true),
+		"exint > 41 AND exint <= 42":      ok(true),
+		"exint < 42 OR exint >= 43":       ok(false),
+		"'10' > 9":                        ok(true),
+		"subject > 1":                     fails(false, cesql.CastError),
+		"nosuch = 1":                      fails(false, cesql.MissingAttributeError),
+		"1 = nosuch":                      fails(false, cesql.MissingAttributeError),
+		"nosuch = FALSE":                  fails(false, cesql.MissingAttributeError),
+		"nosuch <> 'x'":                   fails(false, cesql.MissingAttributeError),
+		"NOT (nosuch = 1)":                fails(false, cesql.MissingAttributeError),
+		"nosuch > 1":                      fails(false, cesql.MissingAttributeError),
+
+		// Arithmetic
+		"1 + 2 * 3":      ok(int32(7)),
+		"(1 + 2) * 3":    ok(int32(9)),
+		"7 / 2":          ok(int32(3)),
+		"-7 % 3":         ok(int32(-1)),
+		"-exint":         ok(int32(-42)),
+		"- -1":           ok(int32(1)),
+		"1 / 0":          fails(int32(0), cesql.MathError),
+		"1 % 0":          fails(int32(0), cesql.MathError),
+		"exint + '1'":    ok(int32(43)),
+		"exint + '1.5'":  fails(int32(42), cesql.CastError),
+		"exint + nosuch": fails(int32(0), cesql.MissingAttributeError),
+		"-nosuch":        fails(int32(0), cesql.MissingAttributeError),
+
+		// Overflows
+		"2147483647 + 1":   fails(int32(2147483647), cesql.MathError),
+		"-2147483648 - 1":  fails(int32(-2147483648), cesql.MathError),
+		"65536 * 65536":    fails(int32(2147483647), cesql.MathError),
+		"-65536 * 65536":   fails(int32(-2147483648), cesql.MathError),
+		"-(-2147483648)":   fails(int32(2147483647), cesql.MathError),
+		"-2147483648 / -1": fails(int32(2147483647), cesql.MathError),
+		"-2147483648 % -1": ok(int32(0)),
+		"2147483646 + 1":   ok(int32(2147483647)),
+		"-2147483647 - 1":  ok(int32(-2147483648)),
+		"-65536 * 32768":   ok(int32(-2147483648)),
+
+		// Logical
+		"NOT exbool":                   ok(false),
+		"NOT 'false'":                  ok(true),
+		"exbool AND NOT FALSE":         ok(true),
+		"TRUE XOR exbool":              ok(false),
+		"TRUE OR FALSE AND FALSE":      ok(true),
+		"FALSE AND nosuch":             ok(false),
+		"TRUE OR nosuch":               ok(true),
+		"TRUE AND nosuch":              fails(false, cesql.MissingAttributeError),
+		"nosuch OR type = 'x'":         fails(false, cesql.MissingAttributeError),
+		"TRUE OR nosuch = 1":           ok(true),
+		"(nosuch = 1) OR TRUE":         fails(false, cesql.MissingAttributeError),
+		"FALSE AND nosuch = 1":         ok(false),
+		"(nosuch = 1) AND FALSE":       fails(false, cesql.MissingAttributeError),
+		"FALSE XOR 'maybe'":            fails(false, cesql.CastError),
+		"NOT (exint = 42)":             ok(false),
+		"exists subject AND exbool":    ok(true),
+		"EXISTS nosuch":                ok(false),
+		"NOT EXISTS nosuch":            ok(true),
+		"EXISTS time AND EXISTS exint": ok(true),
+
+		// LIKE
+		"type LIKE 'com.example.%'":         ok(true),
+		"type LIKE 'com.example._ullEvent'": ok(true),
+		"type LIKE 'com.example.'":          ok(false),
+		"type LIKE 'COM.%'":                 ok(false),
+		"type NOT LIKE 'org.%'":             ok(true),
+		"'a%b' LIKE 'a\\%b'":                ok(true),
+		"'axb' LIKE 'a\\%b'":                ok(false),
+		"'a.b' LIKE 'a_b'":                  ok(true),
+		"'a\nb' LIKE 'a%'":                  ok(true),
+		"exint LIKE '4%'":                   ok(true),
+		"nosuch LIKE '%'":                   fails(false, cesql.MissingAttributeError),
+
+		// IN
+		"subject IN ('a', 'topic')":           ok(true),
+		"subject NOT IN ('a', 'topic')":       ok(false),
+		"exint IN (1, '42', 3)":               ok(true),
+		"exint IN (1, 2 + 2)":                 ok(false),
+		"subject IN ('topic', nosuch)":        fails(false, cesql.MissingAttributeError),
+		"exint IN ('x')":                      fails(false, cesql.CastError),
+		"subject = 'topic' IN (TRUE)":         ok(true),
+		"type LIKE 'com.%' AND exint IN (42)": ok(true),
+	})
+}
+
+func TestEvaluateMinEvent(t *testing.T) {
+	expr, err := cesql.Parse("EXISTS subject OR type LIKE '%.MinEvent'")
+	require.NoError(t, err)
+	got, err := expr.Evaluate(test.MinEvent().Context)
+	require.NoError(t, err)
+	require.Equal(t, true, got)
+}
+
+func TestEvaluateAttributes(t *testing.T) {
+	expr, err := cesql.Parse("type = 'a' AND ext > 1")
+	require.NoError(t, err)
+	got, err := expr.EvaluateAttributes(attributes{"type": "a", "ext": "2"})
+	require.NoError(t, err)
+	require.Equal(t, true, got)
+}
+
+type attributes map[string]interface{}
+
+func (a attributes) Get(name string) interface{} { return a[name] }
+
+func TestType(t *testing.T) {
+	for expression, want := range map[string]cesql.Type{
+		"type":             cesql.Any,
+		"type = 'x'":       cesql.Boolean,
+		"1 + exint":        cesql.Integer,
+		"UPPER(type)":      cesql.String,
+		"'x'":              cesql.String,
+		"EXISTS type":      cesql.Boolean,
+		"exint IN (1, 2)":  cesql.Boolean,
+		"NOT (exint = 42)": cesql.Boolean,
+	} {
+		expr, err := cesql.Parse(expression)
+		require.NoError(t, err)
+		require.Equal(t, want, expr.Type(), expression)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for expression, want := range map[string]cesql.ErrorKind{
+		"":                     cesql.ParseError,
+		"type =":               cesql.ParseError,
+		"type = 'unterminated": cesql.ParseError,
+		"(type = 'x'":          cesql.ParseError,
+		"type = 'x')":          cesql.ParseError,
+		"type NOT 'x'":         cesql.ParseError,
+		"EXISTS 'x'":           cesql.ParseError,
+		"type LIKE subject":    cesql.ParseError,
+		"type IN 'x'":          cesql.ParseError,
+		"exint = 2147483648":   cesql.ParseError,
+		"type # 'x'":           cesql.ParseError,
+		"NOSUCH(type)":         cesql.MissingFunctionError,
+		"LENGTH()":             cesql.MissingFunctionError,
+		"LENGTH(type, type)":   cesql.MissingFunctionError,
+		"TRUE + 1":             cesql.CastError,
+		"1 AND TRUE":           cesql.CastError,
+		"NOT 1":                cesql.CastError,
+		"-TRUE":                cesql.CastError,
+		"1 = TRUE":             cesql.CastError,
+		"1 < FALSE":            cesql.CastError,
+		"TRUE IN (1)":          cesql.CastError,
+		"ABS(TRUE)":            cesql.CastError,
+		"NOT exint = 42":       cesql.CastError,
+	} {
+		t.Run(expression, func(t *testing.T) {
+			_, err := cesql.Parse(expression)
+			require.Error(t, err)
+			require.IsType(t, &cesql.Error{}, err)
+			require.Equal(t, want, err.(*cesql.Error).Kind, err.Error())
+		})
+	}
+}
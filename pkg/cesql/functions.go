@@ -0,0 +1,188 @@
+package cesql
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// function is a built-in function.
+type function struct {
+	name     string
+	params   []Type
+	variadic bool // The last param can be repeated zero or more times
+	returns  Type
+	// impl is invoked with the arguments cast to the param types
+	impl func(args []interface{}) (interface{}, *Error)
+}
+
+// param returns the type of the i-th argument.
+func (f *function) param(i int) Type {
+	if i >= len(f.params) {
+		return f.params[len(f.params)-1]
+	}
+	return f.params[i]
+}
+
+func (f *function) accepts(n int) bool {
+	if f.variadic {
+		return n >= len(f.params)-1
+	}
+	return n == len(f.params)
+}
+
+// functions maps the upper case names to the overloads of the built-in functions.
+var functions = map[string][]*function{}
+
+func addFunction(f *function) {
+	functions[f.name] = append(functions[f.name], f)
+}
+
+// lookupFunction returns the overload of the named function accepting n arguments.
+func lookupFunction(name string, n int) *function {
+	for _, f := range functions[strings.ToUpper(name)] {
+		if f.accepts(n) {
+			return f
+		}
+	}
+	return nil
+}
+
+func fnErr(name string, format string, args ...interface{}) *Error {
+	return newError(FunctionEvaluationError, name+": "+format, args...)
+}
+
+func init() {
+	addFunction(&function{name: "LENGTH", params: []Type{String}, returns: Integer,
+		impl: func(args []interface{}) (interface{}, *Error) {
+			return int32(len([]rune(args[0].(string)))), nil
+		}})
+	addFunction(&function{name: "CONCAT", params: []Type{String}, variadic: true, returns: String,
+		impl: func(args []interface{}) (interface{}, *Error) {
+			return strings.Join(toStrings(args), ""), nil
+		}})
+	addFunction(&function{name: "CONCAT_WS", params: []Type{String, String}, variadic: true, returns: String,
+		impl: func(args []interface{}) (interface{}, *Error) {
+			return strings.Join(toStrings(args[1:]), args[0].(string)), nil
+		}})
+	addFunction(&function{name: "LOWER", params: []Type{String}, returns: String,
+		impl: func(args []interface{}) (interface{}, *Error) {
+			return strings.ToLower(args[0].(string)), nil
+		}})
+	addFunction(&function{name: "UPPER", params: []Type{String}, returns: String,
+		impl: func(args []interface{}) (interface{}, *Error) {
+			return strings.ToUpper(args[0].(string)), nil
+		}})
+	addFunction(&function{name: "TRIM", params: []Type{String}, returns: String,
+		impl: func(args []interface{}) (interface{}, *Error) {
+			return strings.TrimFunc(args[0].(string), unicode.IsSpace), nil
+		}})
+	addFunction(&function{name: "LEFT", params: []Type{String, Integer}, returns: String,
+		impl: func(args []interface{}) (interface{}, *Error) {
+			x, n := []rune(args[0].(string)), int(args[1].(int32))
+			if n < 0 {
+				return string(x), fnErr("LEFT", "negative length %d", n)
+			}
+			if n > len(x) {
+				n = len(x)
+			}
+			return string(x[:n]), nil
+		}})
+	addFunction(&function{name: "RIGHT", params: []Type{String, Integer}, returns: String,
+		impl: func(args []interface{}) (interface{}, *Error) {
+			x, n := []rune(args[0].(string)), int(args[1].(int32))
+			if n < 0 {
+				return string(x), fnErr("RIGHT", "negative length %d", n)
+			}
+			if n > len(x) {
+				n = len(x)
+			}
+			return string(x[len(x)-n:]), nil
+		}})
+	addFunction(&function{name: "SUBSTRING", params: []Type{String, Integer}, returns: String,
+		impl: func(args []interface{}) (interface{}, *Error) {
+			x := []rune(args[0].(string))
+			start, err := substringStart(len(x), args[1].(int32))
+			if err != nil {
+				return "", err
+			}
+			return string(x[start:]), nil
+		}})
+	addFunction(&function{name: "SUBSTRING", params: []Type{String, Integer, Integer}, returns: String,
+		impl: func(args []interface{}) (interface{}, *Error) {
+			x := []rune(args[0].(string))
+			start, err := substringStart(len(x), args[1].(int32))
+			if err != nil {
+				return "", err
+			}
+			n := int(args[2].(int32))
+			if n < 0 {
+				return "", fnErr("SUBSTRING", "negative length %d", n)
+			}
+			if start+n > len(x) {
+				n = len(x) - start
+			}
+			return string(x[start : start+n]), nil
+		}})
+	addFunction(&function{name: "ABS", params: []Type{Integer}, returns: Integer,
+		impl: func(args []interface{}) (interface{}, *Error) {
+			x := args[0].(int32)
+			switch {
+			case x == math.MinInt32:
+				return int32(math.MaxInt32), newError(MathError, "ABS: %d overflows", x)
+			case x < 0:
+				return -x, nil
+			}
+			return x, nil
+		}})
+	addFunction(&function{name: "BOOL", params: []Type{String}, returns: Boolean,
+		impl: castFunction(Boolean)})
+	addFunction(&function{name: "INT", params: []Type{String}, returns: Integer,
+		impl: castFunction(Integer)})
+	addFunction(&function{name: "STRING", params: []Type{Any}, returns: String,
+		impl: castFunction(String)})
+	addFunction(&function{name: "IS_BOOL", params: []Type{String}, returns: Boolean,
+		impl: isFunction(Boolean)})
+	addFunction(&function{name: "IS_INT", params: []Type{String}, returns: Boolean,
+		impl: isFunction(Integer)})
+}
+
+func toStrings(args []interface{}) []string {
+	s := make([]string, len(args))
+	for i, arg := range args {
+		s[i] = arg.(string)
+	}
+	return s
+}
+
+// substringStart converts a 1-based position, negative when counting from the end, to an index.
+func substringStart(length int, pos int32) (int, *Error) {
+	p := int(pos)
+	switch {
+	case p > 0 && p <= length:
+		return p - 1, nil
+	case p < 0 && -p <= length:
+		return length + p, nil
+	default:
+		return 0, fnErr("SUBSTRING", "position %d out of range for a string of length %d", pos, length)
+	}
+}
+
+func castFunction(t Type) func([]interface{}) (interface{}, *Error) {
+	return func(args []interface{}) (interface{}, *Error) {
+		var errs Errors
+		v := cast(args[0], t, &errs)
+		if len(errs) > 0 {
+			return v, errs[0]
+		}
+		return v, nil
+	}
+}
+
+func isFunction(t Type) func([]interface{}) (interface{}, *Error) {
+	return func(args []interface{}) (interface{}, *Error) {
+		var errs Errors
+		cast(args[0], t, &errs)
+		return len(errs) == 0, nil
+	}
+}
@@ -0,0 +1,50 @@
+package cesql_test
+
+import (
+	"testing"
+
+	"github.com/cloudevents/sdk-go/pkg/cesql"
+)
+
+func TestFunctions(t *testing.T) {
+	assertEvaluations(t, map[string]evaluation{
+		"LENGTH(type)":                  ok(int32(21)),
+		"length('héllo')":               ok(int32(5)),
+		"LENGTH(exint)":                 ok(int32(2)),
+		"CONCAT()":                      ok(""),
+		"CONCAT('a', exint, TRUE)":      ok("a42true"),
+		"CONCAT_WS('-')":                ok(""),
+		"CONCAT_WS('-', 'a', 'b', 'c')": ok("a-b-c"),
+		"LOWER(type)":                   ok("com.example.fullevent"),
+		"UPPER('abc')":                  ok("ABC"),
+		"TRIM('  a b \t')":              ok("a b"),
+		"LEFT('abc', 2)":                ok("ab"),
+		"LEFT('abc', 5)":                ok("abc"),
+		"LEFT('abc', -1)":               fails("abc", cesql.FunctionEvaluationError),
+		"RIGHT('abc', 2)":               ok("bc"),
+		"RIGHT('abc', 5)":               ok("abc"),
+		"RIGHT('abc', -1)":              fails("abc", cesql.FunctionEvaluationError),
+		"SUBSTRING('abcdef', 2)":        ok("bcdef"),
+		"SUBSTRING('abcdef', -2)":       ok("ef"),
+		"SUBSTRING('abcdef', 0)":        fails("", cesql.FunctionEvaluationError),
+		"SUBSTRING('abcdef', 7)":        fails("", cesql.FunctionEvaluationError),
+		"SUBSTRING('abcdef', 2, 3)":     ok("bcd"),
+		"SUBSTRING('abcdef', -3, 10)":   ok("def"),
+		"SUBSTRING('abcdef', 2, -1)":    fails("", cesql.FunctionEvaluationError),
+		"ABS(-exint)":                   ok(int32(42)),
+		"ABS(3)":                        ok(int32(3)),
+		"ABS(-2147483648)":              fails(int32(2147483647), cesql.MathError),
+		"BOOL('True')":                  ok(true),
+		"BOOL('yes')":                   fails(false, cesql.CastError),
+		"INT('-12')":                    ok(int32(-12)),
+		"INT('x')":                      fails(int32(0), cesql.CastError),
+		"STRING(exbool)":                ok("true"),
+		"STRING(1 + 1)":                 ok("2"),
+		"IS_BOOL('false')":              ok(true),
+		"IS_BOOL(exint)":                ok(false),
+		"IS_INT(exint)":                 ok(true),
+		"IS_INT('4.2')":                 ok(false),
+		"LENGTH(nosuch)":                fails(int32(0), cesql.MissingAttributeError),
+		"UPPER(SUBSTRING(type, 5, 7)) = 'EXAMPLE'": ok(true),
+	})
+}
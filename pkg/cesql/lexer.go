@@ -0,0 +1,83 @@
+package cesql
+
+import (
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdentifier
+	tokenInteger
+	tokenString
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string // For strings, the unescaped value
+	pos  int
+}
+
+// symbols, longest first
+var symbols = []string{"!=", "<>", "<=", ">=", "(", ")", ",", "=", "<", ">", "+", "-", "*", "/", "%"}
+
+// tokenize splits an expression in tokens, the last token is always tokenEOF.
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '\'' || c == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(s) && s[j] != c; j++ {
+				if s[j] == '\\' && j+1 < len(s) && s[j+1] == c { // Escaped quote
+					j++
+				}
+				b.WriteByte(s[j])
+			}
+			if j == len(s) {
+				return nil, newError(ParseError, "unterminated string literal at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: b.String(), pos: i})
+			i = j + 1
+		case isDigit(c):
+			j := i
+			for j < len(s) && isDigit(s[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenInteger, text: s[i:j], pos: i})
+			i = j
+		case isLetter(c):
+			j := i
+			for j < len(s) && (isLetter(s[j]) || isDigit(s[j]) || s[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, text: s[i:j], pos: i})
+			i = j
+		default:
+			sym := ""
+			for _, candidate := range symbols {
+				if strings.HasPrefix(s[i:], candidate) {
+					sym = candidate
+					break
+				}
+			}
+			if sym == "" {
+				return nil, newError(ParseError, "unexpected character %q at position %d", c, i)
+			}
+			tokens = append(tokens, token{kind: tokenSymbol, text: sym, pos: i})
+			i += len(sym)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(s)}), nil
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
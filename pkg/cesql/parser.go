@@ -0,0 +1,416 @@
+package cesql
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// parser is a recursive descent parser, type checking the nodes while building them.
+//
+// Operators by increasing precedence:
+//
+//	OR XOR
+//	AND
+//	[NOT] IN
+//	[NOT] LIKE
+//	= != <> < <= > >=
+//	+ -
+//	* / %
+//	NOT, unary -
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isKeyword returns true if the token at offset from the current one is the keyword kw.
+func (p *parser) isKeyword(offset int, kw string) bool {
+	if p.pos+offset >= len(p.tokens) {
+		return false
+	}
+	t := p.tokens[p.pos+offset]
+	return t.kind == tokenIdentifier && strings.EqualFold(t.text, kw)
+}
+
+// acceptKeyword consumes the next token if it's one of the keywords.
+func (p *parser) acceptKeyword(keywords ...string) (string, bool) {
+	for _, kw := range keywords {
+		if p.isKeyword(0, kw) {
+			p.pos++
+			return kw, true
+		}
+	}
+	return "", false
+}
+
+// acceptSymbol consumes the next token if it's one of the symbols.
+func (p *parser) acceptSymbol(symbols ...string) (string, bool) {
+	t := p.peek()
+	if t.kind == tokenSymbol {
+		for _, s := range symbols {
+			if t.text == s {
+				p.pos++
+				return s, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expectSymbol(s string) error {
+	if _, ok := p.acceptSymbol(s); !ok {
+		return p.unexpected("expected " + s)
+	}
+	return nil
+}
+
+func (p *parser) unexpected(msg string) error {
+	t := p.peek()
+	if t.kind == tokenEOF {
+		return newError(ParseError, "%s, found end of expression", msg)
+	}
+	return newError(ParseError, "%s, found %q at position %d", msg, p.source(t), t.pos)
+}
+
+func (p *parser) source(t token) string {
+	if t.kind == tokenString {
+		return "'" + t.text + "'"
+	}
+	return t.text
+}
+
+func (p *parser) parseOr() (node, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptKeyword("OR", "XOR")
+		if !ok {
+			return l, nil
+		}
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if l, err = newLogical(op, l, r); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	l, err := p.parseIn()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptKeyword("AND"); !ok {
+			return l, nil
+		}
+		r, err := p.parseIn()
+		if err != nil {
+			return nil, err
+		}
+		if l, err = newLogical("AND", l, r); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseIn() (node, error) {
+	x, err := p.parseLike()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(0, "IN") || p.isKeyword(0, "NOT") && p.isKeyword(1, "IN") {
+		_, negate := p.acceptKeyword("NOT")
+		p.next() // IN
+		if err := p.expectSymbol("("); err != nil {
+			return nil, err
+		}
+		var set []node
+		for {
+			e, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if x.Type() != Any && e.Type() != Any && !castable(e.Type(), x.Type()) {
+				return nil, castErr(e.Type(), x.Type())
+			}
+			set = append(set, e)
+			if _, ok := p.acceptSymbol(","); !ok {
+				break
+			}
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		x = in{negate: negate, x: x, set: set}
+	}
+	return x, nil
+}
+
+func (p *parser) parseLike() (node, error) {
+	x, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(0, "LIKE") || p.isKeyword(0, "NOT") && p.isKeyword(1, "LIKE") {
+		_, negate := p.acceptKeyword("NOT")
+		p.next() // LIKE
+		t := p.peek()
+		if t.kind != tokenString {
+			return nil, p.unexpected("expected LIKE pattern string")
+		}
+		p.next()
+		if err := check(x, String); err != nil {
+			return nil, err
+		}
+		x = like{negate: negate, x: x, pattern: likeRegexp(t.text)}
+	}
+	return x, nil
+}
+
+func (p *parser) parseComparison() (node, error) {
+	l, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptSymbol("=", "!=", "<>", "<", "<=", ">", ">=")
+		if !ok {
+			return l, nil
+		}
+		r, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case "=", "!=", "<>":
+			if l.Type() != Any && r.Type() != Any && !castable(r.Type(), l.Type()) {
+				return nil, castErr(r.Type(), l.Type())
+			}
+			l = equal{negate: op != "=", l: l, r: r}
+		default:
+			if err := check(l, Integer); err != nil {
+				return nil, err
+			}
+			if err := check(r, Integer); err != nil {
+				return nil, err
+			}
+			l = compare{op: op, l: l, r: r}
+		}
+	}
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	l, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptSymbol("+", "-")
+		if !ok {
+			return l, nil
+		}
+		r, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		if l, err = newArithmetic(op, l, r); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseMultiplicative() (node, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptSymbol("*", "/", "%")
+		if !ok {
+			return l, nil
+		}
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if l, err = newArithmetic(op, l, r); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if _, ok := p.acceptKeyword("NOT"); ok {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := check(x, Boolean); err != nil {
+			return nil, err
+		}
+		return not{x}, nil
+	}
+	if _, ok := p.acceptSymbol("-"); ok {
+		if t := p.peek(); t.kind == tokenInteger { // Negative literal, allows math.MinInt32
+			p.next()
+			return parseInteger("-"+t.text, t.pos)
+		}
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := check(x, Integer); err != nil {
+			return nil, err
+		}
+		return negate{x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	if _, ok := p.acceptSymbol("("); ok {
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return x, p.expectSymbol(")")
+	}
+	if b, ok := p.acceptKeyword("TRUE", "FALSE"); ok {
+		return literal{b == "TRUE"}, nil
+	}
+	if _, ok := p.acceptKeyword("EXISTS"); ok {
+		t := p.peek()
+		if t.kind != tokenIdentifier {
+			return nil, p.unexpected("expected attribute name after EXISTS")
+		}
+		p.next()
+		return exists{strings.ToLower(t.text)}, nil
+	}
+
+	t := p.peek()
+	switch t.kind {
+	case tokenString:
+		p.next()
+		return literal{t.text}, nil
+	case tokenInteger:
+		p.next()
+		return parseInteger(t.text, t.pos)
+	case tokenIdentifier:
+		p.next()
+		if _, ok := p.acceptSymbol("("); ok {
+			return p.parseCall(t)
+		}
+		return attribute{strings.ToLower(t.text)}, nil
+	default:
+		return nil, p.unexpected("expected expression")
+	}
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	var args []node
+	if _, ok := p.acceptSymbol(")"); !ok {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok := p.acceptSymbol(","); !ok {
+				break
+			}
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+	}
+	fn := lookupFunction(name.text, len(args))
+	if fn == nil {
+		return nil, newError(MissingFunctionError, "no function %s accepting %d arguments, at position %d", strings.ToUpper(name.text), len(args), name.pos)
+	}
+	for i, arg := range args {
+		if err := check(arg, fn.param(i)); err != nil {
+			return nil, err
+		}
+	}
+	return call{fn: fn, args: args}, nil
+}
+
+func parseInteger(s string, pos int) (node, error) {
+	i, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return nil, newError(ParseError, "invalid integer literal %s at position %d", s, pos)
+	}
+	return literal{int32(i)}, nil
+}
+
+func newLogical(op string, l, r node) (node, error) {
+	if err := check(l, Boolean); err != nil {
+		return nil, err
+	}
+	if err := check(r, Boolean); err != nil {
+		return nil, err
+	}
+	return logical{op: op, l: l, r: r}, nil
+}
+
+func newArithmetic(op string, l, r node) (node, error) {
+	if err := check(l, Integer); err != nil {
+		return nil, err
+	}
+	if err := check(r, Integer); err != nil {
+		return nil, err
+	}
+	return arithmetic{op: op, l: l, r: r}, nil
+}
+
+// check returns an error if the values of n can never be cast to t.
+func check(n node, t Type) error {
+	if !castable(n.Type(), t) {
+		return castErr(n.Type(), t)
+	}
+	return nil
+}
+
+func castErr(from, to Type) error {
+	return newError(CastError, "%s values can't be cast to %s", from, to)
+}
+
+// likeRegexp translates a LIKE pattern to a regular expression.
+// % matches any sequence of characters, _ matches one character, \ escapes the next character.
+func likeRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("(?s)^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
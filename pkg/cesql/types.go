@@ -0,0 +1,102 @@
+package cesql
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/pkg/types"
+)
+
+// Type is a CESQL type.
+type Type int
+
+const (
+	// Boolean values are Go bool.
+	Boolean Type = iota
+	// Integer values are Go int32.
+	Integer
+	// String values are Go string.
+	String
+	// Any is the static type of values known only during the evaluation, like attribute values.
+	Any
+)
+
+var typeNames = []string{"Boolean", "Integer", "String", "Any"}
+
+func (t Type) String() string { return typeNames[t] }
+
+// typeOf returns the type of a CESQL value.
+func typeOf(v interface{}) Type {
+	switch v.(type) {
+	case bool:
+		return Boolean
+	case int32:
+		return Integer
+	default:
+		return String
+	}
+}
+
+// zero returns the default value of t.
+func zero(t Type) interface{} {
+	switch t {
+	case Boolean:
+		return false
+	case Integer:
+		return int32(0)
+	default:
+		return ""
+	}
+}
+
+// castable returns false if a value of type from can never be cast to type to.
+func castable(from, to Type) bool {
+	return !(from == Boolean && to == Integer || from == Integer && to == Boolean)
+}
+
+var integerRegexp = regexp.MustCompile(`^[+-]?[0-9]+$`)
+
+// cast casts v to t. If the cast fails it returns the default value of t and adds a CastError to errs.
+func cast(v interface{}, t Type, errs *Errors) interface{} {
+	if t == Any || typeOf(v) == t {
+		return v
+	}
+	switch t {
+	case String:
+		if s, err := types.Format(v); err == nil {
+			return s
+		}
+	case Integer:
+		if s, ok := v.(string); ok && integerRegexp.MatchString(s) {
+			if i, err := types.ToInteger(s); err == nil {
+				return i
+			}
+		}
+	case Boolean:
+		if s, ok := v.(string); ok {
+			if s = strings.ToLower(s); s == "true" || s == "false" {
+				if b, err := types.ToBool(s); err == nil {
+					return b
+				}
+			}
+		}
+	}
+	errs.add(CastError, "cannot cast %s %#v to %s", typeOf(v), v, t)
+	return zero(t)
+}
+
+// attributeValue maps an attribute value to a CESQL value.
+func attributeValue(v interface{}) (interface{}, error) {
+	v, err := types.Validate(v)
+	if err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case bool, int32:
+		return v, nil
+	case string:
+		return types.ToString(v)
+	default:
+		return types.Format(v)
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"sync"
 
 	"github.com/cloudevents/sdk-go/pkg/binding"
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
 	"github.com/cloudevents/sdk-go/pkg/event"
 	"github.com/cloudevents/sdk-go/pkg/extensions"
+	"github.com/cloudevents/sdk-go/pkg/filter"
 	"github.com/cloudevents/sdk-go/pkg/observability"
 	"github.com/cloudevents/sdk-go/pkg/transport"
 	"github.com/cloudevents/sdk-go/pkg/transport/http"
@@ -85,8 +87,20 @@ type ceClient struct {
 
 	receiverMu        sync.Mutex
 	eventDefaulterFns []EventDefaulter
+	eventVerifierFns  []EventVerifier
 
 	disableTracePropagation bool
+
+	retryParams *cecontext.RetryParams
+
+	filter filter.Filter
+	routes []route
+}
+
+// route delivers the events matching filter to fn.
+type route struct {
+	filter filter.Filter
+	fn     *receiverFn
 }
 
 // Send transmits the provided event on a preconfigured Transport. Send returns
@@ -137,7 +151,7 @@ func (c *ceClient) obsSend(ctx context.Context, event event.Event) error {
 		return err
 	}
 	// Send the event over the transport.
-	return c.transport.Send(ctx, event)
+	return c.transport.Send(c.withRetryParams(ctx), event)
 }
 
 // Request transmits the provided event on a preconfigured Transport. Request
@@ -188,7 +202,7 @@ func (c *ceClient) obsRequest(ctx context.Context, event event.Event) (*event.Ev
 		return nil, err
 	}
 	// Send the event over the transport.
-	return c.transport.Request(ctx, event)
+	return c.transport.Request(c.withRetryParams(ctx), event)
 }
 
 // Delivery is called from from the transport on event delivery.
@@ -219,8 +233,23 @@ func (c *ceClient) Delivery(ctx context.Context, e event.Event, resp *event.Even
 }
 
 func (c *ceClient) obsDelivery(ctx context.Context, e event.Event, resp *event.EventResponse) error {
-	if c.fn != nil {
-		err := c.fn.invoke(ctx, e, resp)
+	for _, verify := range c.eventVerifierFns {
+		if err := verify(ctx, e); err != nil {
+			return fmt.Errorf("event verification failed: %w", err)
+		}
+	}
+	if c.filter != nil && !filter.Match(c.filter, e.Context) {
+		return nil
+	}
+	fn := c.fn
+	for _, r := range c.routes {
+		if filter.Match(r.filter, e.Context) {
+			fn = r.fn
+			break
+		}
+	}
+	if fn != nil {
+		err := fn.invoke(ctx, e, resp)
 
 		// Apply the defaulter chain to the outgoing event.
 		if err == nil && resp != nil && resp.Event != nil && len(c.eventDefaulterFns) > 0 {
@@ -263,6 +292,14 @@ func (c *ceClient) StartReceiver(ctx context.Context, fn interface{}) error {
 	return c.transport.StartReceiver(ctx)
 }
 
+// withRetryParams decorates ctx with the client retry params, unless ctx already has them.
+func (c *ceClient) withRetryParams(ctx context.Context) context.Context {
+	if c.retryParams != nil && cecontext.RetryParamsFrom(ctx) == nil {
+		return cecontext.WithRetryParams(ctx, *c.retryParams)
+	}
+	return ctx
+}
+
 func (c *ceClient) applyOptions(opts ...Option) error {
 	for _, fn := range opts {
 		if err := fn(c); err != nil {
@@ -13,6 +13,10 @@ import (
 // to perform event defaulting.
 type EventDefaulter func(ctx context.Context, event event.Event) event.Event
 
+// EventVerifier is the function signature for extensions that are able
+// to verify the received events. Events failing verification are rejected.
+type EventVerifier func(ctx context.Context, event event.Event) error
+
 // DefaultIDToUUIDIfNotSet will inspect the provided event and assign a UUID to
 // context.ID if it is found to be empty.
 func DefaultIDToUUIDIfNotSet(ctx context.Context, event event.Event) event.Event {
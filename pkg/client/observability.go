@@ -12,6 +12,10 @@ var (
 	// LatencyMs measures the latency in milliseconds for the CloudEvents
 	// client methods.
 	LatencyMs = stats.Float64("cloudevents.io/sdk-go/client/latency", "The latency in milliseconds for the CloudEvents client methods.", "ms")
+
+	// AttemptsCount measures the number of attempts performed by the
+	// transport for the CloudEvents client methods.
+	AttemptsCount = stats.Int64("cloudevents.io/sdk-go/client/attempts", "The number of attempts performed by the transport for the CloudEvents client methods.", stats.UnitDimensionless)
 )
 
 var (
@@ -23,6 +27,16 @@ var (
 		Aggregation: view.Distribution(0, .01, .1, 1, 10, 100, 1000, 10000),
 		TagKeys:     observability.LatencyTags(),
 	}
+
+	// AttemptsView is an OpenCensus view that shows the number of attempts
+	// performed by the transport for client methods.
+	AttemptsView = &view.View{
+		Name:        "client/attempts",
+		Measure:     AttemptsCount,
+		Description: "The distribution of attempts performed by the transport for CloudEvents.",
+		Aggregation: view.Distribution(1, 2, 3, 5, 10),
+		TagKeys:     observability.LatencyTags(),
+	}
 )
 
 type observed int32
@@ -30,6 +44,9 @@ type observed int32
 // Adheres to Observable
 var _ observability.Observable = observed(0)
 
+// Adheres to AttemptsObservable
+var _ observability.AttemptsObservable = observed(0)
+
 const (
 	clientSpanName = "cloudevents.client"
 
@@ -63,6 +80,11 @@ func (o observed) LatencyMs() *stats.Float64Measure {
 	return LatencyMs
 }
 
+// AttemptsCount implements AttemptsObservable.AttemptsCount
+func (o observed) AttemptsCount() *stats.Int64Measure {
+	return AttemptsCount
+}
+
 func eventTraceAttributes(e event.EventContextReader) []trace.Attribute {
 	as := []trace.Attribute{
 		trace.StringAttribute(specversionAttr, e.GetSpecVersion()),
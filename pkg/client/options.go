@@ -2,6 +2,9 @@ package client
 
 import (
 	"fmt"
+
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
+	"github.com/cloudevents/sdk-go/pkg/filter"
 )
 
 // Option is the function signature required to be considered an client.Option.
@@ -18,6 +21,18 @@ func WithEventDefaulter(fn EventDefaulter) Option {
 	}
 }
 
+// WithEventVerifier adds an event verifier, invoked on the received events before they are delivered
+// to the receiver function. The delivery of events failing verification fails with the verifier error.
+func WithEventVerifier(fn EventVerifier) Option {
+	return func(c *ceClient) error {
+		if fn == nil {
+			return fmt.Errorf("client option was given an nil event verifier")
+		}
+		c.eventVerifierFns = append(c.eventVerifierFns, fn)
+		return nil
+	}
+}
+
 // WithUUIDs adds DefaultIDToUUIDIfNotSet event defaulter to the end of the
 // defaulter chain.
 func WithUUIDs() Option {
@@ -54,3 +69,50 @@ func WithoutTracePropagation() Option {
 		return nil
 	}
 }
+
+// WithRetryParams sets the default retry params used by Send and Request when
+// the provided context doesn't already carry retry params.
+// Retry params are honored only by the transports supporting retries, like the http transport.
+func WithRetryParams(params cecontext.RetryParams) Option {
+	return func(c *ceClient) error {
+		if params.Strategy != cecontext.BackoffStrategyNone && params.MaxTries < 1 {
+			return fmt.Errorf("client option was given retry params with invalid max tries: %d", params.MaxTries)
+		}
+		c.retryParams = &params
+		return nil
+	}
+}
+
+// WithFilter drops the received events not matching f before they are delivered
+// to the receiver fn. Dropped events are acknowledged to the transport.
+// When used more than once, events must match all the filters.
+func WithFilter(f filter.Filter) Option {
+	return func(c *ceClient) error {
+		if f == nil {
+			return fmt.Errorf("client option was given an nil filter")
+		}
+		if c.filter != nil {
+			f = filter.All(c.filter, f)
+		}
+		c.filter = f
+		return nil
+	}
+}
+
+// WithRoute delivers the received events matching f to fn, instead of the fn
+// given to StartReceiver. Routes are evaluated in the order they are added
+// and an event is delivered only to the fn of the first matching route.
+// See Client.StartReceiver for the valid fn signatures.
+func WithRoute(f filter.Filter, fn interface{}) Option {
+	return func(c *ceClient) error {
+		if f == nil {
+			return fmt.Errorf("client option was given an nil filter")
+		}
+		r, err := receiver(fn)
+		if err != nil {
+			return err
+		}
+		c.routes = append(c.routes, route{filter: f, fn: r})
+		return nil
+	}
+}
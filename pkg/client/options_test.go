@@ -2,9 +2,13 @@ package client
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
 	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/filter"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -131,3 +135,142 @@ func TestWith_Defaulters(t *testing.T) {
 		})
 	}
 }
+
+func TestWithRetryParams(t *testing.T) {
+	testCases := map[string]struct {
+		c       *ceClient
+		params  cecontext.RetryParams
+		want    *cecontext.RetryParams
+		wantErr string
+	}{
+		"linear": {
+			c:      &ceClient{},
+			params: cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Second, MaxTries: 3},
+			want:   &cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Second, MaxTries: 3},
+		},
+		"no retries": {
+			c:      &ceClient{},
+			params: cecontext.DefaultRetryParams,
+			want:   &cecontext.DefaultRetryParams,
+		},
+		"invalid max tries": {
+			c:       &ceClient{},
+			params:  cecontext.RetryParams{Strategy: cecontext.BackoffStrategyExponential, Period: time.Second},
+			wantErr: "client option was given retry params with invalid max tries: 0",
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			err := tc.c.applyOptions(WithRetryParams(tc.params))
+
+			if tc.wantErr != "" || err != nil {
+				var gotErr string
+				if err != nil {
+					gotErr = err.Error()
+				}
+				if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+					t.Errorf("unexpected error (-want, +got) = %v", diff)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(tc.want, tc.c.retryParams); diff != "" {
+				t.Errorf("unexpected (-want, +got) = %v", diff)
+			}
+
+			ctx := tc.c.withRetryParams(context.Background())
+			if diff := cmp.Diff(tc.want, cecontext.RetryParamsFrom(ctx)); diff != "" {
+				t.Errorf("unexpected (-want, +got) = %v", diff)
+			}
+
+			// Context retry params take precedence
+			ctx = tc.c.withRetryParams(cecontext.WithRetriesLinearBackoff(context.Background(), time.Minute, 10))
+			if diff := cmp.Diff(time.Minute, cecontext.RetryParamsFrom(ctx).Period); diff != "" {
+				t.Errorf("unexpected (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestWithFilterAndRoute(t *testing.T) {
+	var got []string
+	recorder := func(name string) func(event.Event) {
+		return func(e event.Event) { got = append(got, name+":"+e.Type()) }
+	}
+
+	c := &ceClient{}
+	err := c.applyOptions(
+		WithFilter(filter.Prefix(map[string]string{"type": "com.example."})),
+		WithFilter(filter.Not(filter.Exact(map[string]string{"type": "com.example.dropped"}))),
+		WithRoute(filter.Suffix(map[string]string{"type": ".a"}), recorder("a")),
+		WithRoute(filter.Prefix(map[string]string{"type": "com.example.a"}), recorder("never")),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if c.fn, err = receiver(recorder("default")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for _, typ := range []string{"com.example.a", "com.example.b", "com.example.dropped", "org.example.a"} {
+		e := event.New()
+		e.SetType(typ)
+		if err := c.obsDelivery(context.Background(), e, nil); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	want := []string{"a:com.example.a", "default:com.example.b"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
+
+func TestWithFilterNil(t *testing.T) {
+	for n, opt := range map[string]Option{
+		"filter": WithFilter(nil),
+		"route":  WithRoute(nil, func() {}),
+	} {
+		t.Run(n, func(t *testing.T) {
+			err := (&ceClient{}).applyOptions(opt)
+			if diff := cmp.Diff("client option was given an nil filter", err.Error()); diff != "" {
+				t.Errorf("unexpected error (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestWithEventVerifier(t *testing.T) {
+	var got []string
+	c := &ceClient{}
+	err := c.applyOptions(WithEventVerifier(func(_ context.Context, e event.Event) error {
+		if e.Type() != "com.example.verified" {
+			return errors.New("not verified")
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if c.fn, err = receiver(func(e event.Event) { got = append(got, e.Type()) }); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	e := event.New()
+	e.SetType("com.example.verified")
+	if err := c.obsDelivery(context.Background(), e, nil); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	e.SetType("com.example.other")
+	if err := c.obsDelivery(context.Background(), e, nil); err == nil {
+		t.Errorf("expected verification error")
+	}
+	if diff := cmp.Diff([]string{"com.example.verified"}, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+
+	err = (&ceClient{}).applyOptions(WithEventVerifier(nil))
+	if diff := cmp.Diff("client option was given an nil event verifier", err.Error()); diff != "" {
+		t.Errorf("unexpected error (-want, +got) = %v", diff)
+	}
+}
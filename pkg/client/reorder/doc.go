@@ -0,0 +1,19 @@
+/*
+Package reorder delivers events to a handler in the order of their sequence extension.
+
+A Buffer is a receiver for client.StartReceiver. The events are grouped by a key, like the
+source or the partitionkey extension, and the events of each key are delivered in the order of
+their Integer sequence. Out-of-order events are held until the missing events are received, up to a
+bounded window; then the missing events are skipped and the gap is reported with DeliveryFrom:
+
+	b := reorder.New(handler, reorder.WithKey(reorder.ByPartitionKey), reorder.WithTimeout(time.Second))
+	err := c.StartReceiver(ctx, b.Receive)
+
+	func handler(ctx context.Context, e event.Event) error {
+		if gap := reorder.DeliveryFrom(ctx).Gap; gap > 0 {
+			// gap events are missing before e
+		}
+		...
+	}
+*/
+package reorder
@@ -0,0 +1,323 @@
+package reorder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/extensions"
+)
+
+// Handler handles the events in sequence.
+type Handler func(ctx context.Context, e event.Event) error
+
+// KeyFunc returns the key of the sequence the event belongs to.
+type KeyFunc func(e event.Event) string
+
+// BySource orders the events with the same source.
+func BySource(e event.Event) string { return e.Source() }
+
+// ByPartitionKey orders the events with the same partitionkey extension.
+func ByPartitionKey(e event.Event) string {
+	pk, _ := extensions.GetPartitionKey(e)
+	return pk
+}
+
+// Delivery describes the position of a delivered event in its sequence.
+type Delivery struct {
+	// Gap is the number of missing events skipped right before this event.
+	Gap int32
+	// Late is true if the event is received after its position in the sequence has been skipped,
+	// so it's delivered out of order.
+	Late bool
+}
+
+// Opaque key type used to store the Delivery
+type deliveryKeyType struct{}
+
+var deliveryKey = deliveryKeyType{}
+
+// DeliveryFrom returns the Delivery of the event handled with ctx.
+func DeliveryFrom(ctx context.Context) Delivery {
+	if d, ok := ctx.Value(deliveryKey).(Delivery); ok {
+		return d
+	}
+	return Delivery{}
+}
+
+// Buffer delivers the events to a Handler in the order of their Integer sequence extension.
+// Events without an Integer sequence are delivered immediately.
+//
+// The first event received for a key starts its sequence. The following events are held until all
+// the previous events of the sequence are delivered, or the window is full or the timeout expires:
+// then the missing events are skipped.
+//
+// Receive returns nil for held events, so the transport acknowledges them before they are handled:
+// the held events are lost if the process stops. The held events are delivered later with a context
+// carrying the values of the receive context, but not its cancellation, and the errors returned by the
+// Handler for them are logged.
+// The Handler is invoked concurrently for different keys, never for the same key.
+type Buffer struct {
+	handler     Handler
+	key         KeyFunc
+	window      int
+	timeout     time.Duration
+	idleTimeout time.Duration
+
+	mu        sync.Mutex
+	sequences map[string]*sequence
+	lastSweep time.Time
+}
+
+type sequence struct {
+	next       int32 // 0 until the first event is received
+	pending    map[int32]pendingEvent
+	timer      *time.Timer
+	ready      []pendingEvent // events to deliver, in order
+	delivering bool           // a goroutine is delivering the ready events
+	lastActive time.Time
+}
+
+type pendingEvent struct {
+	ctx   context.Context
+	event event.Event
+	call  *receiveCall
+}
+
+// receiveCall is an invocation of Receive, waiting for the result of its event if it's delivered by the same invocation
+type receiveCall struct {
+	err error
+}
+
+// Option is the function signature required to be considered a reorder.Option.
+type Option func(*Buffer)
+
+// WithKey sets the key of the sequences, the default is BySource.
+func WithKey(key KeyFunc) Option {
+	return func(b *Buffer) {
+		b.key = key
+	}
+}
+
+// WithWindow sets the maximum number of events held for each key, the default is 100.
+func WithWindow(window int) Option {
+	return func(b *Buffer) {
+		b.window = window
+	}
+}
+
+// WithTimeout sets the maximum time an event is held, the default is to wait until the window is full.
+func WithTimeout(timeout time.Duration) Option {
+	return func(b *Buffer) {
+		b.timeout = timeout
+	}
+}
+
+// WithIdleTimeout sets the time after which the sequence of a key without held events is forgotten,
+// the default is 10 minutes. The next event of the key starts a new sequence.
+func WithIdleTimeout(timeout time.Duration) Option {
+	return func(b *Buffer) {
+		b.idleTimeout = timeout
+	}
+}
+
+// New returns a Buffer delivering the events to handler.
+func New(handler Handler, opts ...Option) *Buffer {
+	b := &Buffer{
+		handler:     handler,
+		key:         BySource,
+		window:      100,
+		idleTimeout: 10 * time.Minute,
+		sequences:   make(map[string]*sequence),
+		lastSweep:   time.Now(),
+	}
+	for _, o := range opts {
+		o(b)
+	}
+	return b
+}
+
+// Receive is the receiver function to use with client.StartReceiver.
+// It returns the error of the Handler if the event is delivered before Receive returns.
+func (b *Buffer) Receive(ctx context.Context, e event.Event) error {
+	seq, err := integerSequence(e)
+	if err != nil {
+		return b.handler(ctx, e)
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	b.sweep(now)
+	key := b.key(e)
+	s, ok := b.sequences[key]
+	if !ok {
+		s = &sequence{pending: make(map[int32]pendingEvent)}
+		b.sequences[key] = s
+	}
+	s.lastActive = now
+
+	call := &receiveCall{}
+	switch {
+	case s.next == 0 || seq == s.next:
+		s.next = seq + 1
+		s.ready = append(s.ready, pendingEvent{ctx: ctx, event: e, call: call})
+		b.readyPending(s)
+	case seq < s.next:
+		s.ready = append(s.ready, pendingEvent{ctx: context.WithValue(ctx, deliveryKey, Delivery{Late: true}), event: e, call: call})
+	default:
+		s.pending[seq] = pendingEvent{ctx: ctx, event: e, call: call}
+		if len(s.pending) > b.window {
+			b.skip(s)
+		} else if b.timeout > 0 && s.timer == nil {
+			b.startTimer(s)
+		}
+	}
+	b.deliver(s, call)
+	return call.err
+}
+
+// startTimer skips the missing events of s when the timeout expires.
+// Must be called with b.mu held.
+func (b *Buffer) startTimer(s *sequence) {
+	var timer *time.Timer
+	timer = time.AfterFunc(b.timeout, func() {
+		b.mu.Lock()
+		if s.timer != timer { // Stopped
+			b.mu.Unlock()
+			return
+		}
+		s.timer = nil
+		b.skipAll(s)
+		b.deliver(s, nil)
+	})
+	s.timer = timer
+}
+
+// Flush delivers all the held events, skipping the missing ones.
+func (b *Buffer) Flush() {
+	b.mu.Lock()
+	sequences := make([]*sequence, 0, len(b.sequences))
+	for _, s := range b.sequences {
+		sequences = append(sequences, s)
+	}
+	b.mu.Unlock()
+	for _, s := range sequences {
+		b.mu.Lock()
+		b.skipAll(s)
+		b.deliver(s, nil)
+	}
+}
+
+func (b *Buffer) skipAll(s *sequence) {
+	for len(s.pending) > 0 {
+		b.skip(s)
+	}
+}
+
+// skip readies the first held event, skipping the missing events before it.
+func (b *Buffer) skip(s *sequence) {
+	first := true
+	var min int32
+	for seq := range s.pending {
+		if first || seq < min {
+			min, first = seq, false
+		}
+	}
+	p := s.pending[min]
+	delete(s.pending, min)
+	p.ctx = context.WithValue(p.ctx, deliveryKey, Delivery{Gap: min - s.next})
+	s.ready = append(s.ready, p)
+	s.next = min + 1
+	b.readyPending(s)
+}
+
+// readyPending readies the held events following the last readied one.
+// When no event is held anymore, the timer is stopped.
+func (b *Buffer) readyPending(s *sequence) {
+	for {
+		p, ok := s.pending[s.next]
+		if !ok {
+			break
+		}
+		delete(s.pending, s.next)
+		s.next++
+		s.ready = append(s.ready, p)
+	}
+	if len(s.pending) == 0 && s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+}
+
+// deliver delivers the ready events of s in order, outside the lock, unless another goroutine
+// is already delivering them. The result of the event of call is stored in call, the errors of
+// the other events are logged.
+// Must be called with b.mu held, it releases it.
+func (b *Buffer) deliver(s *sequence, call *receiveCall) {
+	if s.delivering {
+		b.mu.Unlock()
+		return
+	}
+	s.delivering = true
+	for len(s.ready) > 0 {
+		p := s.ready[0]
+		s.ready = s.ready[1:]
+		b.mu.Unlock()
+
+		ctx := p.ctx
+		if p.call != call {
+			// The Receive of the event may have returned, keep the values of its context only
+			ctx = detachedContext{ctx}
+		}
+		err := b.handler(ctx, p.event)
+		if p.call == call {
+			call.err = err
+		} else if err != nil {
+			cecontext.LoggerFrom(ctx).Warnw("failed handling a reordered event", zap.Error(err), zap.String("id", p.event.ID()))
+		}
+
+		b.mu.Lock()
+	}
+	s.delivering = false
+	b.mu.Unlock()
+}
+
+// sweep forgets the sequences idle for longer than the idle timeout.
+// Must be called with b.mu held.
+func (b *Buffer) sweep(now time.Time) {
+	if b.idleTimeout <= 0 || now.Sub(b.lastSweep) < b.idleTimeout {
+		return
+	}
+	b.lastSweep = now
+	for key, s := range b.sequences {
+		if len(s.pending) == 0 && len(s.ready) == 0 && !s.delivering && now.Sub(s.lastActive) >= b.idleTimeout {
+			delete(b.sequences, key)
+		}
+	}
+}
+
+// detachedContext carries the values of a context, without its deadline and cancellation.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detachedContext) Done() <-chan struct{} { return nil }
+
+func (detachedContext) Err() error { return nil }
+
+var errNoSequence = errors.New("no sequence extension")
+
+func integerSequence(e event.Event) (int32, error) {
+	s, ok := extensions.GetSequencingExtension(e)
+	if !ok {
+		return 0, errNoSequence
+	}
+	return s.Integer()
+}
@@ -0,0 +1,184 @@
+package reorder_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/client/reorder"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/extensions"
+)
+
+type delivered struct {
+	id       string
+	delivery reorder.Delivery
+}
+
+type recorder struct {
+	mu     sync.Mutex
+	events []delivered
+	// notify, if not nil, receives the id of each event delivered
+	notify chan string
+}
+
+func (r *recorder) handle(ctx context.Context, e event.Event) error {
+	r.mu.Lock()
+	r.events = append(r.events, delivered{e.ID(), reorder.DeliveryFrom(ctx)})
+	r.mu.Unlock()
+	if r.notify != nil {
+		r.notify <- e.ID()
+	}
+	return nil
+}
+
+// wait waits for the delivery of the event with id
+func (r *recorder) wait(t *testing.T, id string) {
+	select {
+	case delivered := <-r.notify:
+		require.Equal(t, id, delivered)
+	case <-time.After(time.Second):
+		t.Fatalf("%s is not delivered", id)
+	}
+}
+
+func (r *recorder) delivered() []delivered {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]delivered(nil), r.events...)
+}
+
+func sequenced(source string, seq int32) event.Event {
+	e := test.MinEvent()
+	e.SetSource(source)
+	e.SetID(source + strconv.Itoa(int(seq)))
+	if err := extensions.IntegerSequence(seq).AddSequencingAttributes(e.Context); err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func receive(t *testing.T, b *reorder.Buffer, events ...event.Event) {
+	for _, e := range events {
+		require.NoError(t, b.Receive(context.Background(), e))
+	}
+}
+
+func TestBufferInOrder(t *testing.T) {
+	r := &recorder{}
+	b := reorder.New(r.handle)
+
+	receive(t, b, sequenced("a", 5), sequenced("b", 1), sequenced("a", 7), sequenced("b", 3),
+		sequenced("a", 6), sequenced("b", 2), test.MinEvent())
+	require.Equal(t, []delivered{{id: "a5"}, {id: "b1"}, {id: "a6"}, {id: "a7"}, {id: "b2"}, {id: "b3"}, {id: test.MinEvent().ID()}}, r.delivered())
+
+	// Late events are delivered as they are received
+	receive(t, b, sequenced("a", 4))
+	require.Equal(t, delivered{"a4", reorder.Delivery{Late: true}}, r.delivered()[7])
+}
+
+func TestBufferWindow(t *testing.T) {
+	r := &recorder{}
+	b := reorder.New(r.handle, reorder.WithWindow(2))
+
+	receive(t, b, sequenced("a", 1), sequenced("a", 4), sequenced("a", 5))
+	require.Len(t, r.delivered(), 1)
+	receive(t, b, sequenced("a", 7))
+	require.Equal(t, []delivered{{id: "a1"}, {"a4", reorder.Delivery{Gap: 2}}, {id: "a5"}}, r.delivered())
+
+	b.Flush()
+	require.Equal(t, delivered{"a7", reorder.Delivery{Gap: 1}}, r.delivered()[3])
+}
+
+func TestBufferTimeout(t *testing.T) {
+	r := &recorder{notify: make(chan string, 1)}
+	b := reorder.New(r.handle, reorder.WithKey(reorder.ByPartitionKey), reorder.WithTimeout(10*time.Millisecond))
+
+	e1, e3 := sequenced("a", 1), sequenced("b", 3)
+	require.NoError(t, extensions.SetPartitionKey(&e1, "key"))
+	require.NoError(t, extensions.SetPartitionKey(&e3, "key"))
+	receive(t, b, e1)
+	r.wait(t, "a1")
+	receive(t, b, e3)
+	require.Len(t, r.delivered(), 1)
+
+	r.wait(t, "b3")
+	require.Equal(t, delivered{"b3", reorder.Delivery{Gap: 1}}, r.delivered()[1])
+}
+
+func TestBufferTimerStoppedWhenGapFilled(t *testing.T) {
+	r := &recorder{notify: make(chan string, 3)}
+	b := reorder.New(r.handle, reorder.WithTimeout(100*time.Millisecond))
+	receive(t, b, sequenced("a", 1), sequenced("a", 3))
+	time.Sleep(60 * time.Millisecond)
+	receive(t, b, sequenced("a", 2), sequenced("a", 5))
+	r.wait(t, "a1")
+	r.wait(t, "a2")
+	r.wait(t, "a3")
+	// The timer of a3 doesn't skip a4
+	time.Sleep(70 * time.Millisecond)
+	require.Len(t, r.delivered(), 3)
+	r.wait(t, "a5")
+	require.Equal(t, delivered{"a5", reorder.Delivery{Gap: 1}}, r.delivered()[3])
+}
+
+func TestBufferHeldEventContext(t *testing.T) {
+	type key struct{}
+	errs := make(chan error, 2)
+	b := reorder.New(func(ctx context.Context, e event.Event) error {
+		if ctx.Value(key{}) != "value" {
+			errs <- errors.New("missing context value")
+		}
+		errs <- ctx.Err()
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	require.NoError(t, b.Receive(ctx, sequenced("a", 1)))
+	require.NoError(t, <-errs)
+	require.NoError(t, b.Receive(ctx, sequenced("a", 3)))
+	cancel()
+	// a3 is delivered after its Receive returned and its context is canceled
+	require.NoError(t, b.Receive(context.WithValue(context.Background(), key{}, "value"), sequenced("a", 2)))
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+}
+
+func TestBufferConcurrentKeys(t *testing.T) {
+	blocked, unblock := make(chan struct{}), make(chan struct{})
+	r := &recorder{}
+	b := reorder.New(func(ctx context.Context, e event.Event) error {
+		if e.ID() == "a1" {
+			close(blocked)
+			<-unblock
+		}
+		return r.handle(ctx, e)
+	})
+	done := make(chan error)
+	go func() { done <- b.Receive(context.Background(), sequenced("a", 1)) }()
+	<-blocked
+	// Other keys are delivered while a1 is handled
+	receive(t, b, sequenced("b", 1))
+	// a2 is delivered after a1, by the goroutine handling a1
+	receive(t, b, sequenced("a", 2))
+	require.Equal(t, []delivered{{id: "b1"}}, r.delivered())
+	close(unblock)
+	require.NoError(t, <-done)
+	require.Equal(t, []delivered{{id: "b1"}, {id: "a1"}, {id: "a2"}}, r.delivered())
+}
+
+func TestBufferIdleTimeout(t *testing.T) {
+	r := &recorder{}
+	b := reorder.New(r.handle, reorder.WithIdleTimeout(time.Millisecond))
+	receive(t, b, sequenced("a", 5))
+	time.Sleep(5 * time.Millisecond)
+	receive(t, b, sequenced("b", 1))
+	// The sequence of a has been forgotten, a3 starts a new sequence
+	receive(t, b, sequenced("a", 3))
+	require.Equal(t, []delivered{{id: "a5"}, {id: "b1"}, {id: "a3"}}, r.delivered())
+}
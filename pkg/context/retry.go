@@ -0,0 +1,142 @@
+package context
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffStrategy is the strategy used to compute the delay between two attempts.
+type BackoffStrategy string
+
+const (
+	// BackoffStrategyNone disables the retries.
+	BackoffStrategyNone BackoffStrategy = ""
+	// BackoffStrategyLinear waits Period * tries between two attempts.
+	BackoffStrategyLinear BackoffStrategy = "linear"
+	// BackoffStrategyExponential waits Period * 2^(tries-1) between two attempts.
+	BackoffStrategyExponential BackoffStrategy = "exponential"
+)
+
+// DefaultRetryParams is the default retry policy, which performs only one attempt.
+var DefaultRetryParams = RetryParams{Strategy: BackoffStrategyNone}
+
+// RetryParams holds the parameters of a retry policy.
+type RetryParams struct {
+	// Strategy is the backoff strategy applied between two attempts.
+	Strategy BackoffStrategy
+
+	// MaxTries is the maximum number of attempts, including the first one.
+	MaxTries int
+
+	// Period is the base delay used by the backoff strategy.
+	Period time.Duration
+
+	// MaxPeriod caps the delay between two attempts. Zero means no cap.
+	MaxPeriod time.Duration
+
+	// Jitter is the fraction, between 0 and 1, of the delay which is randomized.
+	// For example, a Jitter of 0.2 makes the delay randomly vary between 80% and 100% of the computed value.
+	Jitter float64
+}
+
+// Attempts returns the maximum number of attempts allowed by these RetryParams.
+// It's always at least 1.
+func (r RetryParams) Attempts() int {
+	if r.Strategy == BackoffStrategyNone || r.MaxTries < 1 {
+		return 1
+	}
+	return r.MaxTries
+}
+
+// BackoffFor returns the delay to wait after the given number of tries, before attempting again.
+func (r RetryParams) BackoffFor(tries int) time.Duration {
+	if tries < 1 {
+		tries = 1
+	}
+
+	var delay time.Duration
+	switch r.Strategy {
+	case BackoffStrategyLinear:
+		delay = r.Period * time.Duration(tries)
+	case BackoffStrategyExponential:
+		exp := math.Pow(2, float64(tries-1))
+		if exp > float64(math.MaxInt64)/float64(r.Period+1) {
+			delay = time.Duration(math.MaxInt64)
+		} else {
+			delay = r.Period * time.Duration(exp)
+		}
+	default:
+		return 0
+	}
+
+	if r.MaxPeriod > 0 && delay > r.MaxPeriod {
+		delay = r.MaxPeriod
+	}
+
+	if r.Jitter > 0 {
+		jitter := math.Min(r.Jitter, 1)
+		delay -= time.Duration(float64(delay) * jitter * rand.Float64())
+	}
+	return delay
+}
+
+// Backoff blocks for the delay computed by BackoffFor(tries) or until ctx is done.
+// Returns ctx.Err() if ctx is done before the end of the delay.
+func (r RetryParams) Backoff(ctx context.Context, tries int) error {
+	return Sleep(ctx, r.BackoffFor(tries))
+}
+
+// Sleep blocks for the provided delay or until ctx is done.
+// Returns ctx.Err() if ctx is done before the end of the delay.
+func Sleep(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Opaque key type used to store retry params
+type retryParamsKeyType struct{}
+
+var retryParamsKey = retryParamsKeyType{}
+
+// WithRetryParams returns back a new context with the given retry params.
+// Retry params are honored by the senders supporting retries, like the http one.
+func WithRetryParams(ctx context.Context, params RetryParams) context.Context {
+	return context.WithValue(ctx, retryParamsKey, params)
+}
+
+// WithRetriesLinearBackoff returns back a new context with retry params using linear backoff strategy.
+func WithRetriesLinearBackoff(ctx context.Context, period time.Duration, maxTries int) context.Context {
+	return WithRetryParams(ctx, RetryParams{
+		Strategy: BackoffStrategyLinear,
+		Period:   period,
+		MaxTries: maxTries,
+	})
+}
+
+// WithRetriesExponentialBackoff returns back a new context with retry params using exponential backoff strategy.
+func WithRetriesExponentialBackoff(ctx context.Context, period time.Duration, maxTries int) context.Context {
+	return WithRetryParams(ctx, RetryParams{
+		Strategy: BackoffStrategyExponential,
+		Period:   period,
+		MaxTries: maxTries,
+	})
+}
+
+// RetryParamsFrom looks in the given context and returns the retry params if found, otherwise nil.
+func RetryParamsFrom(ctx context.Context) *RetryParams {
+	if p, ok := ctx.Value(retryParamsKey).(RetryParams); ok {
+		return &p
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package context_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
+)
+
+func TestRetryParamsBackoffFor(t *testing.T) {
+	testCases := map[string]struct {
+		params cecontext.RetryParams
+		tries  int
+		want   time.Duration
+	}{
+		"none": {
+			params: cecontext.RetryParams{Period: time.Second, MaxTries: 3},
+			tries:  2,
+			want:   0,
+		},
+		"linear, first try": {
+			params: cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Second},
+			tries:  1,
+			want:   time.Second,
+		},
+		"linear, third try": {
+			params: cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Second},
+			tries:  3,
+			want:   3 * time.Second,
+		},
+		"exponential, first try": {
+			params: cecontext.RetryParams{Strategy: cecontext.BackoffStrategyExponential, Period: time.Second},
+			tries:  1,
+			want:   time.Second,
+		},
+		"exponential, fourth try": {
+			params: cecontext.RetryParams{Strategy: cecontext.BackoffStrategyExponential, Period: time.Second},
+			tries:  4,
+			want:   8 * time.Second,
+		},
+		"exponential, capped": {
+			params: cecontext.RetryParams{Strategy: cecontext.BackoffStrategyExponential, Period: time.Second, MaxPeriod: 5 * time.Second},
+			tries:  10,
+			want:   5 * time.Second,
+		},
+		"exponential, overflow": {
+			params: cecontext.RetryParams{Strategy: cecontext.BackoffStrategyExponential, Period: time.Second, MaxPeriod: time.Minute},
+			tries:  200,
+			want:   time.Minute,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got := tc.params.BackoffFor(tc.tries)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestRetryParamsJitter(t *testing.T) {
+	params := cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		got := params.BackoffFor(1)
+		if got < 500*time.Millisecond || got > time.Second {
+			t.Errorf("backoff %v out of the jitter bounds", got)
+		}
+	}
+}
+
+func TestRetryParamsAttempts(t *testing.T) {
+	testCases := map[string]struct {
+		params cecontext.RetryParams
+		want   int
+	}{
+		"default": {
+			params: cecontext.DefaultRetryParams,
+			want:   1,
+		},
+		"none with max tries": {
+			params: cecontext.RetryParams{MaxTries: 5},
+			want:   1,
+		},
+		"linear": {
+			params: cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, MaxTries: 5},
+			want:   5,
+		},
+		"linear without max tries": {
+			params: cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear},
+			want:   1,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.params.Attempts()); diff != "" {
+				t.Errorf("unexpected (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestRetryParamsContext(t *testing.T) {
+	if got := cecontext.RetryParamsFrom(context.TODO()); got != nil {
+		t.Errorf("expected nil retry params, got %v", got)
+	}
+
+	ctx := cecontext.WithRetriesExponentialBackoff(context.TODO(), time.Second, 3)
+	want := &cecontext.RetryParams{Strategy: cecontext.BackoffStrategyExponential, Period: time.Second, MaxTries: 3}
+	if diff := cmp.Diff(want, cecontext.RetryParamsFrom(ctx)); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+
+	ctx = cecontext.WithRetriesLinearBackoff(context.TODO(), time.Second, 3)
+	want = &cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Second, MaxTries: 3}
+	if diff := cmp.Diff(want, cecontext.RetryParamsFrom(ctx)); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
+
+func TestSleepCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	if err := cecontext.Sleep(ctx, time.Hour); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -6,6 +6,8 @@ const (
 	ApplicationXML                  = "application/xml"
 	ApplicationCloudEventsJSON      = "application/cloudevents+json"
 	ApplicationCloudEventsBatchJSON = "application/cloudevents-batch+json"
+	ApplicationCloudEventsProtobuf  = "application/cloudevents+protobuf"
+	ApplicationCloudEventsAvro      = "application/cloudevents+avro"
 )
 
 // StringOfApplicationJSON returns a string pointer to "application/json"
@@ -0,0 +1,29 @@
+package extensions
+
+import (
+	"net/url"
+
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/types"
+)
+
+// DataRefExtension is the name of the extension defined by the Dataref extension:
+// https://github.com/cloudevents/spec/blob/master/extensions/dataref.md
+const DataRefExtension = "dataref"
+
+// GetDataRef returns the dataref extension of the event
+func GetDataRef(event event.Event) (*url.URL, bool) {
+	if ref, ok := event.Extensions()[DataRefExtension]; ok {
+		if refStr, err := types.Format(ref); err == nil {
+			if u, err := url.Parse(refStr); err == nil && refStr != "" {
+				return u, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// SetDataRef sets the dataref extension of the event
+func SetDataRef(event *event.Event, dataRef *url.URL) error {
+	return event.Context.SetExtension(DataRefExtension, types.URIRef{URL: *dataRef})
+}
@@ -0,0 +1,56 @@
+package extensions
+
+import (
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/types"
+)
+
+// PartitionKeyExtension is the name of the extension defined by the Partitioning extension:
+// https://github.com/cloudevents/spec/blob/master/extensions/partitioning.md
+const PartitionKeyExtension = "partitionkey"
+
+// GetPartitionKey returns the partitionkey extension of the event
+func GetPartitionKey(event event.Event) (string, bool) {
+	if pk, ok := event.Extensions()[PartitionKeyExtension]; ok {
+		if pkStr, err := types.ToString(pk); err == nil && pkStr != "" {
+			return pkStr, true
+		}
+	}
+	return "", false
+}
+
+// SetPartitionKey sets the partitionkey extension of the event
+func SetPartitionKey(event *event.Event, partitionKey string) error {
+	return event.Context.SetExtension(PartitionKeyExtension, partitionKey)
+}
+
+// PartitionKeyTransformer returns a transformer which sets the partitionkey extension, if missing,
+// to the value returned by partitionKey. No extension is set if partitionKey returns an empty string.
+// The transformer works on events, so the messages are converted to events while encoding.
+func PartitionKeyTransformer(partitionKey func(event.Event) (string, error)) binding.TransformerFactory {
+	return partitionKeyTransformerFactory(partitionKey)
+}
+
+type partitionKeyTransformerFactory func(event.Event) (string, error)
+
+func (f partitionKeyTransformerFactory) StructuredTransformer(binding.StructuredWriter) binding.StructuredWriter {
+	return nil
+}
+
+func (f partitionKeyTransformerFactory) BinaryTransformer(binding.BinaryWriter) binding.BinaryWriter {
+	return nil
+}
+
+func (f partitionKeyTransformerFactory) EventTransformer() binding.EventTransformer {
+	return func(e *event.Event) error {
+		if _, ok := GetPartitionKey(*e); ok {
+			return nil
+		}
+		pk, err := f(*e)
+		if err != nil || pk == "" {
+			return err
+		}
+		return SetPartitionKey(e, pk)
+	}
+}
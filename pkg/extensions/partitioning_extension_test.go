@@ -0,0 +1,66 @@
+package extensions_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/extensions"
+)
+
+func TestPartitionKey(t *testing.T) {
+	e := test.MinEvent()
+	_, ok := extensions.GetPartitionKey(e)
+	require.False(t, ok)
+
+	require.NoError(t, extensions.SetPartitionKey(&e, "aKey"))
+	pk, ok := extensions.GetPartitionKey(e)
+	require.True(t, ok)
+	require.Equal(t, "aKey", pk)
+}
+
+func TestPartitionKeyTransformer(t *testing.T) {
+	bySubject := extensions.PartitionKeyTransformer(func(e event.Event) (string, error) {
+		return e.Subject(), nil
+	})
+
+	tests := []struct {
+		name  string
+		event event.Event
+		want  string
+	}{
+		{"set from function", test.FullEvent(), "topic"},
+		{"existing partitionkey", func() event.Event {
+			e := test.FullEvent()
+			e.SetExtension(extensions.PartitionKeyExtension, "existing")
+			return e
+		}(), "existing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, m := range []binding.Message{binding.EventMessage(tt.event), test.MustCreateMockBinaryMessage(tt.event)} {
+				e, err := binding.ToEvent(context.TODO(), m, binding.TransformerFactories{bySubject})
+				require.NoError(t, err)
+				pk, ok := extensions.GetPartitionKey(*e)
+				require.True(t, ok)
+				require.Equal(t, tt.want, pk)
+			}
+		})
+	}
+
+	e, err := binding.ToEvent(context.TODO(), binding.EventMessage(test.MinEvent()), binding.TransformerFactories{bySubject})
+	require.NoError(t, err)
+	_, ok := extensions.GetPartitionKey(*e)
+	require.False(t, ok)
+
+	failing := extensions.PartitionKeyTransformer(func(e event.Event) (string, error) {
+		return "", errors.New("no key")
+	})
+	_, err = binding.ToEvent(context.TODO(), binding.EventMessage(test.MinEvent()), binding.TransformerFactories{failing})
+	require.Error(t, err)
+}
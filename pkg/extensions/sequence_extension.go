@@ -0,0 +1,65 @@
+package extensions
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/types"
+)
+
+// Extensions defined by the Sequence extension:
+// https://github.com/cloudevents/spec/blob/master/extensions/sequence.md
+const (
+	SequenceExtension     = "sequence"
+	SequenceTypeExtension = "sequencetype"
+
+	// SequenceTypeInteger is the sequencetype of sequences of signed 32 bit integers, starting from 1
+	SequenceTypeInteger = "Integer"
+)
+
+// SequencingExtension represents the sequence and sequencetype extensions
+type SequencingExtension struct {
+	Sequence     string `json:"sequence"`
+	SequenceType string `json:"sequencetype"`
+}
+
+// IntegerSequence returns a SequencingExtension for the Integer sequence value
+func IntegerSequence(sequence int32) SequencingExtension {
+	return SequencingExtension{Sequence: strconv.Itoa(int(sequence)), SequenceType: SequenceTypeInteger}
+}
+
+// AddSequencingAttributes adds the sequence attributes sequence and sequencetype to the cloudevents context
+func (s SequencingExtension) AddSequencingAttributes(ec EventTracer) error {
+	if s.Sequence == "" {
+		return nil
+	}
+	if err := ec.SetExtension(SequenceExtension, s.Sequence); err != nil {
+		return err
+	}
+	if s.SequenceType != "" {
+		return ec.SetExtension(SequenceTypeExtension, s.SequenceType)
+	}
+	return nil
+}
+
+// Integer returns the value of an Integer sequence
+func (s SequencingExtension) Integer() (int32, error) {
+	if s.SequenceType != SequenceTypeInteger {
+		return 0, fmt.Errorf("sequencetype is %q, not %q", s.SequenceType, SequenceTypeInteger)
+	}
+	return types.ToInteger(s.Sequence)
+}
+
+func GetSequencingExtension(event event.Event) (SequencingExtension, bool) {
+	if seq, ok := event.Extensions()[SequenceExtension]; ok {
+		if seqStr, err := types.Format(seq); err == nil {
+			var typeStr string
+			if st, ok := event.Extensions()[SequenceTypeExtension]; ok {
+				typeStr, _ = types.ToString(st)
+			}
+			return SequencingExtension{Sequence: seqStr, SequenceType: typeStr}, true
+		}
+	}
+	return SequencingExtension{}, false
+}
@@ -0,0 +1,35 @@
+package extensions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/extensions"
+)
+
+func TestSequencingExtension(t *testing.T) {
+	e := test.MinEvent()
+	_, ok := extensions.GetSequencingExtension(e)
+	require.False(t, ok)
+
+	require.NoError(t, extensions.IntegerSequence(42).AddSequencingAttributes(e.Context))
+	require.Equal(t, "42", e.Extensions()[extensions.SequenceExtension])
+	require.Equal(t, extensions.SequenceTypeInteger, e.Extensions()[extensions.SequenceTypeExtension])
+
+	s, ok := extensions.GetSequencingExtension(e)
+	require.True(t, ok)
+	require.Equal(t, extensions.SequencingExtension{Sequence: "42", SequenceType: "Integer"}, s)
+	seq, err := s.Integer()
+	require.NoError(t, err)
+	require.Equal(t, int32(42), seq)
+
+	e = test.MinEvent()
+	require.NoError(t, extensions.SequencingExtension{Sequence: "abc"}.AddSequencingAttributes(e.Context))
+	s, ok = extensions.GetSequencingExtension(e)
+	require.True(t, ok)
+	require.Equal(t, extensions.SequencingExtension{Sequence: "abc"}, s)
+	_, err = s.Integer()
+	require.Error(t, err)
+}
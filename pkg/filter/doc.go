@@ -0,0 +1,21 @@
+/*
+Package filter evaluates conditions on the context attributes of events,
+implementing the filter dialects of the CloudEvents Subscriptions API:
+Exact, Prefix, Suffix, All, Any, Not and SQL.
+
+Filters are evaluated against an event.EventContextReader with Match, or
+directly against the attributes of a binary mode binding.MessageReader with
+MatchMessage, without converting the message to an event.Event:
+
+	f := filter.All(
+		filter.Prefix(map[string]string{"type": "com.example."}),
+		filter.Not(filter.Exact(map[string]string{"source": "/internal"})),
+	)
+	if filter.Match(f, e.Context) {
+		// ...
+	}
+
+Attribute values are compared using their canonical string representation,
+see the types package. A filter referencing a missing attribute doesn't match.
+*/
+package filter
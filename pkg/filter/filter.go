@@ -0,0 +1,151 @@
+package filter
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/spec"
+	"github.com/cloudevents/sdk-go/pkg/cesql"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/types"
+)
+
+// Attributes gives access to the context attributes of an event by name, see cesql.Attributes.
+type Attributes = cesql.Attributes
+
+// Filter is a condition on the context attributes of an event.
+type Filter interface {
+	// Match returns true if the attributes satisfy the filter.
+	Match(Attributes) bool
+}
+
+// Match evaluates f against an event context.
+func Match(f Filter, c event.EventContextReader) bool {
+	return f.Match(cesql.ContextAttributes(c))
+}
+
+// MatchMessage evaluates f against the attributes of a message, without converting it to an event.Event.
+// The message data is not read, so the message can still be read afterwards.
+//
+// Structured messages can't be inspected without parsing the whole event: for them MatchMessage
+// returns binding.ErrNotBinary, convert them with binding.ToEvent and use Match instead.
+func MatchMessage(ctx context.Context, f Filter, m binding.MessageReader) (bool, error) {
+	attrs := messageAttributes{}
+	if err := m.ReadBinary(ctx, attrs); err != nil {
+		return false, err
+	}
+	return f.Match(attrs), nil
+}
+
+// Exact matches if the canonical string value of each attribute in attrs is equal to the given value.
+func Exact(attrs map[string]string) Filter {
+	return compare(attrs, func(value, want string) bool { return value == want })
+}
+
+// Prefix matches if the canonical string value of each attribute in attrs starts with the given prefix.
+func Prefix(attrs map[string]string) Filter {
+	return compare(attrs, strings.HasPrefix)
+}
+
+// Suffix matches if the canonical string value of each attribute in attrs ends with the given suffix.
+func Suffix(attrs map[string]string) Filter {
+	return compare(attrs, strings.HasSuffix)
+}
+
+// All matches if all filters match. It matches when no filters are given.
+func All(filters ...Filter) Filter { return allFilter(filters) }
+
+// Any matches if at least one of the filters matches. It doesn't match when no filters are given.
+func Any(filters ...Filter) Filter { return anyFilter(filters) }
+
+// Not matches if f doesn't match.
+func Not(f Filter) Filter { return notFilter{f} }
+
+// Func adapts a function to a Filter.
+type Func func(Attributes) bool
+
+func (fn Func) Match(a Attributes) bool { return fn(a) }
+
+type compareFilter struct {
+	attrs map[string]string
+	cmp   func(value, operand string) bool
+}
+
+func compare(attrs map[string]string, cmp func(value, operand string) bool) Filter {
+	lower := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		lower[strings.ToLower(k)] = v
+	}
+	return compareFilter{attrs: lower, cmp: cmp}
+}
+
+func (f compareFilter) Match(a Attributes) bool {
+	for name, operand := range f.attrs {
+		s, ok := stringValue(a, name)
+		if !ok || !f.cmp(s, operand) {
+			return false
+		}
+	}
+	return true
+}
+
+type allFilter []Filter
+
+func (f allFilter) Match(a Attributes) bool {
+	for _, filter := range f {
+		if !filter.Match(a) {
+			return false
+		}
+	}
+	return true
+}
+
+type anyFilter []Filter
+
+func (f anyFilter) Match(a Attributes) bool {
+	for _, filter := range f {
+		if filter.Match(a) {
+			return true
+		}
+	}
+	return false
+}
+
+type notFilter struct{ f Filter }
+
+func (f notFilter) Match(a Attributes) bool { return !f.f.Match(a) }
+
+// stringValue returns the canonical string value of the named attribute.
+func stringValue(a Attributes, name string) (string, bool) {
+	v := a.Get(name)
+	if v == nil {
+		return "", false
+	}
+	s, err := types.Format(v)
+	return s, err == nil
+}
+
+// messageAttributes collects the attributes of a binary message, ignoring the data.
+type messageAttributes map[string]interface{}
+
+func (a messageAttributes) Get(name string) interface{} { return a[name] }
+
+func (a messageAttributes) Start(ctx context.Context) error { return nil }
+
+func (a messageAttributes) SetAttribute(attribute spec.Attribute, value interface{}) error {
+	a[attribute.Name()] = value
+	return nil
+}
+
+func (a messageAttributes) SetExtension(name string, value interface{}) error {
+	a[strings.ToLower(name)] = value
+	return nil
+}
+
+func (a messageAttributes) SetData(io.Reader) error { return nil }
+
+func (a messageAttributes) End() error { return nil }
+
+var _ binding.BinaryWriter = messageAttributes{} // Test it conforms to the interface
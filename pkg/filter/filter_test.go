@@ -0,0 +1,76 @@
+package filter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/filter"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter filter.Filter
+		want   bool
+	}{
+		{"exact", filter.Exact(map[string]string{"type": "com.example.FullEvent", "subject": "topic"}), true},
+		{"exact mismatch", filter.Exact(map[string]string{"type": "com.example.FullEvent", "subject": "other"}), false},
+		{"exact case sensitive value", filter.Exact(map[string]string{"type": "com.example.fullevent"}), false},
+		{"exact attribute name case", filter.Exact(map[string]string{"TYPE": "com.example.FullEvent"}), true},
+		{"exact extension", filter.Exact(map[string]string{"exint": "42", "exbool": "true"}), true},
+		{"exact time", filter.Exact(map[string]string{"time": "2020-03-21T12:34:56.78Z"}), true},
+		{"exact missing", filter.Exact(map[string]string{"nosuch": ""}), false},
+		{"prefix", filter.Prefix(map[string]string{"type": "com.example.", "source": "http://"}), true},
+		{"prefix mismatch", filter.Prefix(map[string]string{"type": "org.example."}), false},
+		{"suffix", filter.Suffix(map[string]string{"type": "FullEvent"}), true},
+		{"suffix mismatch", filter.Suffix(map[string]string{"type": "MinEvent"}), false},
+		{"all", filter.All(filter.Prefix(map[string]string{"type": "com."}), filter.Suffix(map[string]string{"type": "Event"})), true},
+		{"all mismatch", filter.All(filter.Prefix(map[string]string{"type": "com."}), filter.Suffix(map[string]string{"type": "x"})), false},
+		{"all empty", filter.All(), true},
+		{"any", filter.Any(filter.Prefix(map[string]string{"type": "org."}), filter.Suffix(map[string]string{"type": "Event"})), true},
+		{"any mismatch", filter.Any(filter.Prefix(map[string]string{"type": "org."}), filter.Suffix(map[string]string{"type": "x"})), false},
+		{"any empty", filter.Any(), false},
+		{"not", filter.Not(filter.Exact(map[string]string{"type": "x"})), true},
+		{"not mismatch", filter.Not(filter.Exact(map[string]string{"subject": "topic"})), false},
+		{"func", filter.Func(func(a filter.Attributes) bool { return a.Get("exstring") == "exstring" }), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := test.FullEvent()
+			require.Equal(t, tt.want, filter.Match(tt.filter, e.Context))
+
+			for _, m := range []binding.Message{binding.EventMessage(e), test.MustCreateMockBinaryMessage(e)} {
+				got, err := filter.MatchMessage(context.Background(), tt.filter, m)
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMatchMessageStructured(t *testing.T) {
+	m := test.MustCreateMockStructuredMessage(test.FullEvent())
+	_, err := filter.MatchMessage(context.Background(), filter.All(), m)
+	require.Equal(t, binding.ErrNotBinary, err)
+}
+
+func TestMatchMessageKeepsData(t *testing.T) {
+	e := test.FullEvent()
+	m := test.MustCreateMockBinaryMessage(e)
+	got, err := filter.MatchMessage(context.Background(), filter.Exact(map[string]string{"id": e.ID()}), m)
+	require.NoError(t, err)
+	require.True(t, got)
+	test.AssertEventEquals(t, test.ExToStr(t, e), test.ExToStr(t, test.MustToEvent(t, context.Background(), m)))
+}
+
+func TestMatchVersions(t *testing.T) {
+	test.EachEvent(t, test.Events(), func(t *testing.T, e event.Event) {
+		f := filter.Exact(map[string]string{"specversion": e.SpecVersion(), "id": e.ID()})
+		require.True(t, filter.Match(f, e.Context))
+	})
+}
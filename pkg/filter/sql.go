@@ -0,0 +1,32 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/cloudevents/sdk-go/pkg/cesql"
+)
+
+// SQL returns the filter for a CloudEvents SQL expression, see the cesql package.
+//
+// The filter matches if the expression evaluates to true. Evaluation errors,
+// like a reference to a missing attribute, make the filter not match, even when NOT is used
+// inside the expression: use EXISTS to match the events without an attribute.
+// Not inverts the result of the filter instead, so Not(SQL("x = 1")) matches the events without x.
+// Expressions that can only evaluate to integers or strings are rejected.
+func SQL(expression string) (Filter, error) {
+	e, err := cesql.Parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SQL expression %q: %v", expression, err)
+	}
+	if t := e.Type(); t != cesql.Boolean && t != cesql.Any {
+		return nil, fmt.Errorf("invalid SQL expression %q: evaluates to %s instead of Boolean", expression, t)
+	}
+	return sqlFilter{e}, nil
+}
+
+type sqlFilter struct{ e cesql.Expression }
+
+func (f sqlFilter) Match(a Attributes) bool {
+	v, err := f.e.EvaluateAttributes(a)
+	return err == nil && v == true
+}
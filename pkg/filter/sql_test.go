@@ -0,0 +1,81 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/filter"
+)
+
+func TestSQL(t *testing.T) {
+	tests := map[string]bool{
+		"type = 'com.example.FullEvent'":                 true,
+		"TYPE = \"com.example.FullEvent\"":               true,
+		"type <> 'com.example.FullEvent'":                false,
+		"type != 'x'":                                    true,
+		"type LIKE 'com.example.%'":                      true,
+		"type LIKE 'com.example._ullEvent'":              true,
+		"type LIKE 'com.example.'":                       false,
+		"type NOT LIKE 'org.%'":                          true,
+		"subject LIKE 'to\\_ic'":                         false,
+		"EXISTS subject":                                 true,
+		"EXISTS nosuch":                                  false,
+		"NOT EXISTS nosuch":                              true,
+		"exint = 42":                                     true,
+		"exint = '42'":                                   true,
+		"exbool":                                         true,
+		"exbool = TRUE AND exint = 42":                   true,
+		"exbool = false OR subject = 'topic'":            true,
+		"exbool XOR subject = 'topic'":                   false,
+		"NOT (exint = 42 AND type = 'x')":                true,
+		"nosuch = 'x' OR type = 'com.example.FullEvent'": false,
+		"exstring = 'it\\'s'":                            false,
+		"exint":                                          false,
+		"EXISTS exurl AND exurl LIKE 'http://example.%'": true,
+		"nosuch = 1":                                     false,
+		"nosuch <> 1":                                    false,
+		"NOT (nosuch = 1)":                               false,
+		"NOT EXISTS nosuch OR nosuch = 1":                true,
+	}
+	e := test.FullEvent()
+	for expr, want := range tests {
+		t.Run(expr, func(t *testing.T) {
+			f, err := filter.SQL(expr)
+			require.NoError(t, err)
+			require.Equal(t, want, filter.Match(f, e.Context))
+		})
+	}
+}
+
+func TestSQLNot(t *testing.T) {
+	e := test.FullEvent()
+	f, err := filter.SQL("nosuch = 1")
+	require.NoError(t, err)
+	// Not inverts the filter, so it matches when the evaluation fails
+	require.False(t, filter.Match(f, e.Context))
+	require.True(t, filter.Match(filter.Not(f), e.Context))
+}
+
+func TestSQLInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"type =",
+		"type = 'unterminated",
+		"(type = 'x'",
+		"type = 'x')",
+		"type NOT 'x'",
+		"EXISTS 'x'",
+		"type LIKE subject",
+		"exint = 99999999999",
+		"exint + 1",
+		"UPPER(type)",
+		"NOSUCH(type)",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := filter.SQL(expr)
+			require.Error(t, err)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package observability
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opencensus.io/stats"
@@ -16,6 +17,13 @@ type Observable interface {
 	LatencyMs() *stats.Float64Measure
 }
 
+// AttemptsObservable is an Observable whose operations can be attempted several times,
+// e.g. when a send is retried. The Reporter records the number of attempts in AttemptsCount.
+type AttemptsObservable interface {
+	Observable
+	AttemptsCount() *stats.Int64Measure
+}
+
 // Reporter represents a running latency counter. When Error or OK are
 // called, the latency is calculated. Error or OK are only allowed to
 // be called once.
@@ -25,12 +33,18 @@ type Reporter interface {
 }
 
 type reporter struct {
-	ctx   context.Context
-	on    Observable
-	start time.Time
-	once  sync.Once
+	ctx      context.Context
+	on       Observable
+	start    time.Time
+	once     sync.Once
+	attempts int32
 }
 
+// Opaque key type used to store the reporter
+type reporterKeyType struct{}
+
+var reporterKey = reporterKeyType{}
+
 // All tags used for Latency measurements.
 func LatencyTags() []tag.Key {
 	return []tag.Key{KeyMethod, KeyResult}
@@ -47,7 +61,15 @@ func NewReporter(ctx context.Context, on Observable) (context.Context, Reporter)
 		start: time.Now(),
 	}
 	r.tagMethod()
-	return ctx, r
+	return context.WithValue(ctx, reporterKey, r), r
+}
+
+// ReportAttempt notifies the Reporter stored in ctx, if any, that the observed method
+// is performing a new attempt of the operation.
+func ReportAttempt(ctx context.Context) {
+	if r, ok := ctx.Value(reporterKey).(*reporter); ok {
+		atomic.AddInt32(&r.attempts, 1)
+	}
 }
 
 func (r *reporter) tagMethod() {
@@ -61,6 +83,11 @@ func (r *reporter) tagMethod() {
 func (r *reporter) record() {
 	ms := float64(time.Since(r.start) / time.Millisecond)
 	stats.Record(r.ctx, r.on.LatencyMs().M(ms))
+	if ao, ok := r.on.(AttemptsObservable); ok {
+		if attempts := atomic.LoadInt32(&r.attempts); attempts > 0 {
+			stats.Record(r.ctx, ao.AttemptsCount().M(int64(attempts)))
+		}
+	}
 }
 
 // Error records the result as an error.
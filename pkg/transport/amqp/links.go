@@ -0,0 +1,348 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"pack.ag/amqp"
+
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
+)
+
+// linkSession is the connection and session where the links are created
+type linkSession interface {
+	newSender(address string) (amqpSender, error)
+	newReceiver(opts ...amqp.LinkOption) (amqpReceiver, error)
+	close() error
+}
+
+// amqpSession implements linkSession with an amqp.Client and an amqp.Session
+type amqpSession struct {
+	client   *amqp.Client
+	session  *amqp.Session
+	linkOpts []amqp.LinkOption
+}
+
+func dialSession(server string, connOpts []amqp.ConnOption, sessionOpts []amqp.SessionOption, linkOpts []amqp.LinkOption) (*amqpSession, error) {
+	client, err := amqp.Dial(server, connOpts...)
+	if err != nil {
+		return nil, err
+	}
+	session, err := client.NewSession(sessionOpts...)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	return &amqpSession{client: client, session: session, linkOpts: linkOpts}, nil
+}
+
+func (s *amqpSession) newSender(address string) (amqpSender, error) {
+	opts := append(append([]amqp.LinkOption(nil), s.linkOpts...), amqp.LinkTargetAddress(address))
+	sender, err := s.session.NewSender(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return sender, nil
+}
+
+func (s *amqpSession) newReceiver(opts ...amqp.LinkOption) (amqpReceiver, error) {
+	receiver, err := s.session.NewReceiver(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return receiver, nil
+}
+
+func (s *amqpSession) close() error {
+	if s.client == nil { // The session is owned by the caller
+		return nil
+	}
+	return s.client.Close()
+}
+
+// errLinksClosed is returned after senderLinks is closed
+var errLinksClosed = errors.New("amqp sender links closed")
+
+// maxSenderLinks is the number of sender links kept attached by senderLinks
+var maxSenderLinks = 100
+
+// senderLinks is a pool of sender links keyed by target address, implementing amqpSender.
+// The links are created lazily, on the first message sent to their address, and they are
+// created again when they are detached. When the connection fails it is dialed again.
+// The connection is dialed, and the links attached, without holding the lock, so the links already
+// attached are used while another link is attached.
+// At most max links are kept: the least recently used link, other than the one to address, is closed
+// when a link to a new address is attached. This bounds the links to the reply-to addresses,
+// which are usually dynamic nodes used by a single requester.
+type senderLinks struct {
+	address string
+	dial    func() (linkSession, error)
+	max     int
+
+	mu      sync.Mutex
+	conn    linkSession
+	dialing chan struct{} // closed when the dial in progress ends, nil if none
+	links   map[string]*pooledLink
+	clock   uint64 // incremented each time a link is used
+	closed  bool
+}
+
+// pooledLink is a sender link of the pool, usable when ready is closed and err is nil
+type pooledLink struct {
+	conn  linkSession
+	link  amqpSender
+	err   error
+	ready chan struct{}
+	used  uint64 // clock of the last use
+}
+
+func newSenderLinks(address string, dial func() (linkSession, error)) *senderLinks {
+	return &senderLinks{address: address, dial: dial, max: maxSenderLinks, links: make(map[string]*pooledLink)}
+}
+
+// Send sends msg on the link of the target address of ctx, see targetAddress.
+// If the link is detached, or the connection fails, msg is sent again once on a new link.
+func (p *senderLinks) Send(ctx context.Context, msg *amqp.Message) error {
+	address := targetAddress(ctx, p.address)
+	l, err := p.link(address)
+	if err != nil {
+		return err
+	}
+	err = l.link.Send(ctx, msg)
+	if err == nil || !isLinkError(err) || ctx.Err() != nil {
+		return err
+	}
+	p.drop(address, l, err)
+	if l, err = p.link(address); err != nil {
+		return err
+	}
+	return l.link.Send(ctx, msg)
+}
+
+// link returns the link of address, attaching it if needed.
+// If the attach fails because the connection failed, the connection is dialed again and the attach is retried once.
+func (p *senderLinks) link(address string) (*pooledLink, error) {
+	for retry := true; ; retry = false {
+		conn, err := p.session()
+		if err != nil {
+			return nil, err
+		}
+		p.mu.Lock()
+		p.clock++
+		l, ok := p.links[address]
+		if ok && l.conn == conn {
+			l.used = p.clock
+			p.mu.Unlock()
+			<-l.ready
+			if l.err != nil {
+				return nil, l.err
+			}
+			return l, nil
+		}
+		l = &pooledLink{conn: conn, ready: make(chan struct{}), used: p.clock}
+		p.links[address] = l
+		evicted := p.evict()
+		p.mu.Unlock()
+
+		for _, e := range evicted {
+			_ = e.link.Close(context.Background())
+		}
+		l.link, l.err = conn.newSender(address)
+		close(l.ready)
+		if l.err == nil {
+			return l, nil
+		}
+		p.mu.Lock()
+		if p.links[address] == l {
+			delete(p.links, address)
+		}
+		p.mu.Unlock()
+		if !retry || !isConnError(l.err) {
+			return nil, l.err
+		}
+		p.dropSession(conn)
+	}
+}
+
+// evict removes the least recently used attached links, other than the link to address,
+// while there are more than max links. The caller holds the lock and closes the links returned.
+func (p *senderLinks) evict() []*pooledLink {
+	var evicted []*pooledLink
+	for p.max > 0 && len(p.links) > p.max {
+		var oldest *pooledLink
+		var oldestAddress string
+		for address, l := range p.links {
+			if address == p.address || !isReady(l) || l.err != nil {
+				continue
+			}
+			if oldest == nil || l.used < oldest.used {
+				oldest, oldestAddress = l, address
+			}
+		}
+		if oldest == nil {
+			break
+		}
+		evicted = append(evicted, oldest)
+		delete(p.links, oldestAddress)
+	}
+	return evicted
+}
+
+func isReady(l *pooledLink) bool {
+	select {
+	case <-l.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// newReceiver attaches a receiver link on the connection of the sender links.
+// If the attach fails because the connection failed, the connection is dialed again and the attach is retried once.
+func (p *senderLinks) newReceiver(opts ...amqp.LinkOption) (amqpReceiver, error) {
+	for retry := true; ; retry = false {
+		conn, err := p.session()
+		if err != nil {
+			return nil, err
+		}
+		receiver, err := conn.newReceiver(opts...)
+		if err == nil || !retry || !isConnError(err) {
+			return receiver, err
+		}
+		p.dropSession(conn)
+	}
+}
+
+// session returns the connection, dialing it if needed.
+// Concurrent callers wait for the same dial.
+func (p *senderLinks) session() (linkSession, error) {
+	p.mu.Lock()
+	for p.dialing != nil {
+		dialing := p.dialing
+		p.mu.Unlock()
+		<-dialing
+		p.mu.Lock()
+	}
+	if p.closed {
+		p.mu.Unlock()
+		return nil, errLinksClosed
+	}
+	if p.conn != nil {
+		conn := p.conn
+		p.mu.Unlock()
+		return conn, nil
+	}
+	dialing := make(chan struct{})
+	p.dialing = dialing
+	p.mu.Unlock()
+
+	conn, err := p.dial()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dialing = nil
+	close(dialing)
+	if err != nil {
+		return nil, err
+	}
+	if p.closed {
+		_ = conn.close()
+		return nil, errLinksClosed
+	}
+	p.conn = conn
+	return conn, nil
+}
+
+// drop removes the link failed with err, and its connection if err is a connection failure
+func (p *senderLinks) drop(address string, l *pooledLink, err error) {
+	if isConnError(err) {
+		p.dropSession(l.conn)
+		return
+	}
+	p.mu.Lock()
+	if p.links[address] == l {
+		delete(p.links, address)
+	}
+	p.mu.Unlock()
+	// The link is already detached, Close only releases it
+	_ = l.link.Close(context.Background())
+}
+
+// dropSession closes the failed connection conn and forgets its links,
+// unless the connection has already been dialed again.
+func (p *senderLinks) dropSession(conn linkSession) {
+	p.mu.Lock()
+	if p.conn != conn {
+		p.mu.Unlock()
+		return
+	}
+	p.conn = nil
+	for address, l := range p.links {
+		if l.conn == conn {
+			delete(p.links, address)
+		}
+	}
+	p.mu.Unlock()
+	_ = conn.close()
+}
+
+// Close closes the links and the connection
+func (p *senderLinks) Close(ctx context.Context) error {
+	p.mu.Lock()
+	p.closed = true
+	links, conn := p.links, p.conn
+	p.links, p.conn = make(map[string]*pooledLink), nil
+	p.mu.Unlock()
+
+	var err error
+	for _, l := range links {
+		<-l.ready
+		if l.err != nil {
+			continue
+		}
+		if e := l.link.Close(ctx); err == nil {
+			err = e
+		}
+	}
+	if conn != nil {
+		if e := conn.close(); err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// targetAddress returns the address of the node where the message is sent: the topic set with
+// cecontext.WithTopic, or the target set with cecontext.WithTarget, or address if none is set.
+// The path of amqp and amqps target URLs is used as address, other targets are used as they are.
+func targetAddress(ctx context.Context, address string) string {
+	if topic := cecontext.TopicFrom(ctx); topic != "" {
+		return topic
+	}
+	if target := cecontext.TargetFrom(ctx); target != nil {
+		if target.Scheme == "amqp" || target.Scheme == "amqps" {
+			return strings.TrimPrefix(target.Path, "/")
+		}
+		return target.String()
+	}
+	return address
+}
+
+// isConnError reports if err is caused by the failure of the connection or of the session
+func isConnError(err error) bool {
+	if errors.Is(err, amqp.ErrConnClosed) || errors.Is(err, amqp.ErrSessionClosed) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// isLinkError reports if err is caused by the detach of the link or by the failure of the connection
+func isLinkError(err error) bool {
+	var detachErr *amqp.DetachError
+	return errors.Is(err, amqp.ErrLinkClosed) || errors.As(err, &detachErr) || isConnError(err)
+}
@@ -0,0 +1,362 @@
+package amqp
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"pack.ag/amqp"
+
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
+)
+
+// fakeSession records the links created on it
+type fakeSession struct {
+	mu          sync.Mutex
+	senders     map[string]*fakeSender
+	senderErr   error
+	block       map[string]chan struct{} // newSender waits for the channel of the address
+	attaching   chan string              // if not nil, receives the addresses newSender waits for
+	receivers   []*fakeReceiverLink
+	receiverErr error
+	closed      bool
+}
+
+func (s *fakeSession) newSender(address string) (amqpSender, error) {
+	s.mu.Lock()
+	block, attaching := s.block[address], s.attaching
+	s.mu.Unlock()
+	if block != nil {
+		if attaching != nil {
+			attaching <- address
+		}
+		<-block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.senderErr != nil {
+		return nil, s.senderErr
+	}
+	l := &fakeSender{}
+	s.senders[address] = l
+	return l, nil
+}
+
+func (s *fakeSession) newReceiver(opts ...amqp.LinkOption) (amqpReceiver, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.receiverErr != nil {
+		return nil, s.receiverErr
+	}
+	l := &fakeReceiverLink{messages: make(chan *amqp.Message, 1), failed: make(chan struct{})}
+	s.receivers = append(s.receivers, l)
+	return l, nil
+}
+
+// receiver returns the i-th receiver link attached, or nil
+func (s *fakeSession) receiver(i int) *fakeReceiverLink {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i >= len(s.receivers) {
+		return nil
+	}
+	return s.receivers[i]
+}
+
+func (s *fakeSession) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+// fakeReceiverLink receives messages, until it fails with err
+type fakeReceiverLink struct {
+	messages chan *amqp.Message
+	failed   chan struct{}
+	once     sync.Once
+	err      error
+}
+
+func (l *fakeReceiverLink) Receive(ctx context.Context) (*amqp.Message, error) {
+	select {
+	case m := <-l.messages:
+		return m, nil
+	case <-l.failed:
+		return nil, l.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *fakeReceiverLink) fail(err error) {
+	l.once.Do(func() {
+		l.err = err
+		close(l.failed)
+	})
+}
+
+func (l *fakeReceiverLink) Address() string { return "" }
+
+func (l *fakeReceiverLink) Close(ctx context.Context) error {
+	l.fail(amqp.ErrLinkClosed)
+	return nil
+}
+
+// fakeSender counts the messages sent, or fails with err
+type fakeSender struct {
+	mu     sync.Mutex
+	sent   int
+	msgs   []*amqp.Message
+	err    error
+	closed bool
+}
+
+func (l *fakeSender) Send(ctx context.Context, msg *amqp.Message) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return l.err
+	}
+	l.sent++
+	l.msgs = append(l.msgs, msg)
+	return nil
+}
+
+func (l *fakeSender) Close(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	return nil
+}
+
+type fakeDialer struct {
+	mu       sync.Mutex
+	sessions []*fakeSession
+	block    chan struct{} // dial waits for block, if not nil
+}
+
+func (d *fakeDialer) dial() (linkSession, error) {
+	if d.block != nil {
+		<-d.block
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	s := &fakeSession{senders: make(map[string]*fakeSender), block: make(map[string]chan struct{})}
+	d.sessions = append(d.sessions, s)
+	return s, nil
+}
+
+func (d *fakeDialer) last() *fakeSession {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.sessions[len(d.sessions)-1]
+}
+
+func (d *fakeDialer) dials() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.sessions)
+}
+
+func TestSenderLinksAddress(t *testing.T) {
+	d := &fakeDialer{}
+	p := newSenderLinks("default", d.dial)
+	ctx := context.Background()
+
+	for _, ctx := range []context.Context{
+		ctx,
+		cecontext.WithTopic(ctx, "topic"),
+		cecontext.WithTarget(ctx, "amqp://localhost/url"),
+		cecontext.WithTarget(ctx, "queue://target"),
+		cecontext.WithTopic(cecontext.WithTarget(ctx, "target"), "topic"),
+		ctx,
+	} {
+		require.NoError(t, p.Send(ctx, &amqp.Message{}))
+	}
+
+	require.Len(t, d.sessions, 1)
+	senders := d.last().senders
+	require.Len(t, senders, 4)
+	require.Equal(t, 2, senders["default"].sent)
+	require.Equal(t, 2, senders["topic"].sent)
+	require.Equal(t, 1, senders["url"].sent)
+	require.Equal(t, 1, senders["queue://target"].sent)
+
+	require.NoError(t, p.Close(ctx))
+	require.True(t, d.last().closed)
+	require.True(t, senders["default"].closed)
+	require.Error(t, p.Send(ctx, &amqp.Message{}))
+}
+
+func TestSenderLinksDetached(t *testing.T) {
+	d := &fakeDialer{}
+	p := newSenderLinks("default", d.dial)
+	ctx := context.Background()
+
+	require.NoError(t, p.Send(ctx, &amqp.Message{}))
+	detached := d.last().senders["default"]
+	detached.err = &amqp.DetachError{}
+
+	require.NoError(t, p.Send(ctx, &amqp.Message{}))
+	require.True(t, detached.closed)
+	require.Len(t, d.sessions, 1)
+	require.NotEqual(t, detached, d.last().senders["default"])
+	require.Equal(t, 1, d.last().senders["default"].sent)
+}
+
+func TestSenderLinksRedial(t *testing.T) {
+	d := &fakeDialer{}
+	p := newSenderLinks("default", d.dial)
+	ctx := context.Background()
+
+	require.NoError(t, p.Send(ctx, &amqp.Message{}))
+	failed := d.last()
+	failed.senders["default"].err = amqp.ErrConnClosed
+	failed.senderErr = amqp.ErrConnClosed
+
+	require.NoError(t, p.Send(ctx, &amqp.Message{}))
+	require.Len(t, d.sessions, 2)
+	require.True(t, failed.closed)
+	require.Equal(t, 1, d.last().senders["default"].sent)
+
+	// A new link on a failed connection dials it again
+	d.last().senderErr = amqp.ErrSessionClosed
+	require.NoError(t, p.Send(cecontext.WithTopic(ctx, "topic"), &amqp.Message{}))
+	require.Len(t, d.sessions, 3)
+	require.Equal(t, 1, d.last().senders["topic"].sent)
+}
+
+func TestSenderLinksRejected(t *testing.T) {
+	d := &fakeDialer{}
+	p := newSenderLinks("default", d.dial)
+	ctx := context.Background()
+
+	require.NoError(t, p.Send(ctx, &amqp.Message{}))
+	rejected := &amqp.Error{Condition: amqp.ErrorInternalError}
+	link := d.last().senders["default"]
+	link.err = rejected
+
+	require.Equal(t, rejected, p.Send(ctx, &amqp.Message{}))
+	require.False(t, link.closed)
+	require.Len(t, d.sessions, 1)
+	require.Len(t, d.last().senders, 1)
+}
+
+func TestSenderLinksAttachWithoutLock(t *testing.T) {
+	d := &fakeDialer{}
+	p := newSenderLinks("default", d.dial)
+	ctx := context.Background()
+	require.NoError(t, p.Send(ctx, &amqp.Message{}))
+
+	block, attaching := make(chan struct{}), make(chan string)
+	d.last().mu.Lock()
+	d.last().block["slow"], d.last().attaching = block, attaching
+	d.last().mu.Unlock()
+	done := make(chan error)
+	go func() { done <- p.Send(cecontext.WithTopic(ctx, "slow"), &amqp.Message{}) }()
+	require.Equal(t, "slow", <-attaching)
+
+	// The attached links are used while another link is attached
+	require.NoError(t, p.Send(ctx, &amqp.Message{}))
+	close(block)
+	require.NoError(t, <-done)
+	require.Equal(t, 1, d.last().senders["slow"].sent)
+}
+
+func TestSenderLinksConcurrentDial(t *testing.T) {
+	d := &fakeDialer{block: make(chan struct{})}
+	p := newSenderLinks("default", d.dial)
+
+	const count = 10
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		go func() { errs <- p.Send(context.Background(), &amqp.Message{}) }()
+	}
+	close(d.block)
+	for i := 0; i < count; i++ {
+		require.NoError(t, <-errs)
+	}
+	require.Equal(t, 1, d.dials())
+	require.Equal(t, count, d.last().senders["default"].sent)
+}
+
+func TestReceiverReattach(t *testing.T) {
+	defer func(delay time.Duration) { reattachDelay = delay }(reattachDelay)
+	reattachDelay = time.Millisecond
+
+	d := &fakeDialer{}
+	p := newSenderLinks("default", d.dial)
+	attach := func() (amqpReceiver, error) { return p.newReceiver() }
+	link, err := attach()
+	require.NoError(t, err)
+	r := &receiver{amqp: link, attach: attach, replies: p}
+	ctx := context.Background()
+
+	// A detached link is attached again on the same connection
+	d.last().receiver(0).fail(&amqp.DetachError{})
+	go func() {
+		for d.last().receiver(1) == nil {
+			time.Sleep(time.Millisecond)
+		}
+		d.last().receiver(1).messages <- &amqp.Message{}
+	}()
+	m, err := r.Receive(ctx)
+	require.NoError(t, err)
+	require.Equal(t, p, m.(*Message).replies)
+	require.Equal(t, 1, d.dials())
+
+	// After a connection failure the connection is dialed again
+	failed := d.last()
+	failed.mu.Lock()
+	failed.receiverErr = amqp.ErrConnClosed
+	failed.mu.Unlock()
+	failed.receiver(1).fail(amqp.ErrConnClosed)
+	go func() {
+		for d.dials() < 2 || d.last().receiver(0) == nil {
+			time.Sleep(time.Millisecond)
+		}
+		d.last().receiver(0).messages <- &amqp.Message{}
+	}()
+	_, err = r.Receive(ctx)
+	require.NoError(t, err)
+	failed.mu.Lock()
+	require.True(t, failed.closed)
+	failed.mu.Unlock()
+
+	// A closed receiver is not attached again
+	require.NoError(t, r.Close(ctx))
+	_, err = r.Receive(ctx)
+	require.Equal(t, amqp.ErrLinkClosed, err)
+	require.Equal(t, 2, d.dials())
+}
+
+func TestSenderLinksEvict(t *testing.T) {
+	d := &fakeDialer{}
+	p := newSenderLinks("default", d.dial)
+	p.max = 3
+	ctx := context.Background()
+	send := func(address string) {
+		require.NoError(t, p.Send(cecontext.WithTopic(ctx, address), &amqp.Message{}))
+	}
+
+	send("default")
+	send("a")
+	send("b")
+	send("a")
+	// The least recently used link is closed, but not the link to the default address
+	send("c")
+	senders := d.last().senders
+	require.True(t, senders["b"].closed)
+	require.False(t, senders["a"].closed)
+	require.False(t, senders["default"].closed)
+	require.Len(t, p.links, 3)
+	require.Nil(t, p.links["b"])
+
+	// The evicted link is attached again when used
+	send("b")
+	require.True(t, senders["a"].closed)
+	require.Equal(t, 1, d.last().senders["b"].sent)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/cloudevents/sdk-go/pkg/binding"
 	"github.com/cloudevents/sdk-go/pkg/binding/format"
 	"github.com/cloudevents/sdk-go/pkg/binding/spec"
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
 )
 
 const prefix = "cloudEvents:" // Name prefix for AMQP properties that hold CE attributes.
@@ -27,6 +28,10 @@ var (
 type Message struct {
 	AMQP     *amqp.Message
 	encoding binding.Encoding
+
+	replies amqpSender
+	resp    binding.Message
+	respCtx context.Context
 }
 
 // Wrap an *amqp.Message in a binding.Message.
@@ -43,6 +48,9 @@ func NewMessage(message *amqp.Message) *Message {
 
 var _ binding.Message = (*Message)(nil)
 
+// Check if amqp.Message implements binding.ResponseMessage
+var _ binding.ResponseMessage = (*Message)(nil)
+
 func getSpecVersion(message *amqp.Message) spec.Version {
 	if sv, ok := message.ApplicationProperties[specs.PrefixedSpecVersionName()]; ok {
 		if svs, ok := sv.(string); ok {
@@ -108,12 +116,63 @@ func (m *Message) ReadBinary(ctx context.Context, encoder binding.BinaryWriter)
 	return encoder.End()
 }
 
+// Response sets the response sent to the reply-to address of the request when the message is finished.
+// The response has the same encoding of the request and the correlation-id of the request,
+// or its message-id if the request has no correlation-id.
+// Responses are sent only by the receivers created with WithReplySession.
+func (m *Message) Response(ctx context.Context, resp binding.Message) {
+	m.resp = resp
+	m.respCtx = ctx
+}
+
+// Finish accepts the message, or rejects it if err is not nil.
+// If the message is accepted, the response, if any, is sent to the reply-to address of the request.
 func (m *Message) Finish(err error) error {
+	var respErr error
+	if resp := m.resp; resp != nil {
+		m.resp = nil
+		if err == nil {
+			respErr = m.respond(resp)
+		} else {
+			_ = resp.Finish(err)
+		}
+	}
 	if err != nil {
 		return m.AMQP.Reject(&amqp.Error{
 			Condition:   condition,
 			Description: err.Error(),
 		})
 	}
-	return m.AMQP.Accept()
+	if err := m.AMQP.Accept(); err != nil {
+		return err
+	}
+	return respErr
+}
+
+// respond sends resp to the reply-to address of the message, on the pooled sender link of the address.
+func (m *Message) respond(resp binding.Message) (err error) {
+	defer func() { _ = resp.Finish(err) }()
+	if m.replies == nil || m.AMQP.Properties == nil || m.AMQP.Properties.ReplyTo == "" {
+		return nil
+	}
+	ctx := m.respCtx
+	if m.encoding == binding.EncodingBinary {
+		ctx = binding.WithForceBinary(ctx)
+	} else {
+		ctx = binding.WithForceStructured(ctx)
+	}
+	var reply amqp.Message
+	if err = WriteAMQPMessage(ctx, resp, &reply, nil); err != nil {
+		return err
+	}
+	if reply.Properties == nil {
+		reply.Properties = &amqp.MessageProperties{}
+	}
+	reply.Properties.CorrelationID = m.AMQP.Properties.CorrelationID
+	if reply.Properties.CorrelationID == nil {
+		reply.Properties.CorrelationID = m.AMQP.Properties.MessageID
+	}
+
+	err = toResult(m.replies.Send(cecontext.WithTopic(ctx, m.AMQP.Properties.ReplyTo), &reply))
+	return err
 }
@@ -0,0 +1,38 @@
+package amqp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"pack.ag/amqp"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+)
+
+func TestMessageRespond(t *testing.T) {
+	d := &fakeDialer{}
+	replies := newSenderLinks("default", d.dial)
+	request := func(messageID, correlationID interface{}) *Message {
+		m := NewMessage(&amqp.Message{Properties: &amqp.MessageProperties{
+			ReplyTo:       "reply-address",
+			MessageID:     messageID,
+			CorrelationID: correlationID,
+		}})
+		m.replies = replies
+		m.respCtx = context.Background()
+		return m
+	}
+
+	require.NoError(t, request("id1", nil).respond(binding.EventMessage(test.FullEvent())))
+	require.NoError(t, request("id2", "correlation").respond(binding.EventMessage(test.FullEvent())))
+
+	// The responses are sent on the same pooled link of the reply-to address
+	require.Len(t, d.sessions, 1)
+	require.Len(t, d.last().senders, 1)
+	sent := d.last().senders["reply-address"].msgs
+	require.Len(t, sent, 2)
+	require.Equal(t, "id1", sent[0].Properties.CorrelationID)
+	require.Equal(t, "correlation", sent[1].Properties.CorrelationID)
+}
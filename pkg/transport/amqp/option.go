@@ -1,6 +1,12 @@
 package amqp
 
-import "github.com/cloudevents/sdk-go/pkg/binding"
+import (
+	"time"
+
+	"pack.ag/amqp"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+)
 
 // amqp.Sender options
 type SenderOptionFunc func(sender *sender)
@@ -11,3 +17,36 @@ func WithTransformer(transformer binding.TransformerFactory) SenderOptionFunc {
 		sender.transformers = append(sender.transformers, transformer)
 	}
 }
+
+// Set the time Requester waits for a response, when the context passed to Request has no deadline.
+// Defaults to DefaultRequestTimeout, 0 waits until the context is done.
+func WithRequestTimeout(timeout time.Duration) SenderOptionFunc {
+	return func(sender *sender) {
+		sender.requestTimeout = timeout
+	}
+}
+
+// amqp.Receiver options
+type ReceiverOptionFunc func(receiver *receiver)
+
+// Send the responses to the requests received by Receiver to their reply-to address,
+// using sender links of session. A sender link is created for each reply-to address and reused
+// for the following responses, until the receiver is closed. At most 100 links are kept attached:
+// the least recently used one is closed when a link to a new reply-to address is created.
+func WithReplySession(session *amqp.Session) ReceiverOptionFunc {
+	return func(receiver *receiver) {
+		replies := newSenderLinks("", func() (linkSession, error) {
+			return &amqpSession{session: session}, nil
+		})
+		receiver.replies = replies
+		receiver.ownedReplies = replies
+	}
+}
+
+// Send the responses with replies
+func withReplies(replies amqpSender) ReceiverOptionFunc {
+	return func(receiver *receiver) {
+		receiver.replies = replies
+		receiver.ownedReplies = nil
+	}
+}
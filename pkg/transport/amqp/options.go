@@ -49,3 +49,11 @@ func WithReceiverLinkOption(opt amqp.LinkOption) Option {
 		return nil
 	}
 }
+
+// WithSenderOptions supplies the options of the Sender and Requester created by the transport
+func WithSenderOptions(opts ...SenderOptionFunc) Option {
+	return func(t *Transport) error {
+		t.senderOpts = append(t.senderOpts, opts...)
+		return nil
+	}
+}
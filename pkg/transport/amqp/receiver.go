@@ -2,27 +2,104 @@ package amqp
 
 import (
 	"context"
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
 
 	"github.com/cloudevents/sdk-go/pkg/binding"
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
 	"github.com/cloudevents/sdk-go/pkg/transport"
 	"pack.ag/amqp"
 )
 
+// reattachDelay is the delay between the attempts to attach again a failed receiver link
+var reattachDelay = time.Second
+
 // receiver wraps an amqp.Receiver as a binding.Receiver
-type receiver struct{ amqp *amqp.Receiver }
+type receiver struct {
+	mu     sync.Mutex
+	amqp   amqpReceiver
+	closed bool
+	// attach, if not nil, attaches the receiver link again when it is detached or its connection fails
+	attach  func() (amqpReceiver, error)
+	replies amqpSender
+	// ownedReplies are the reply links created with WithReplySession, closed with the receiver
+	ownedReplies *senderLinks
+}
 
+// Receive returns the next message. If the receiver link fails and it can be attached again,
+// Receive attaches it and waits for the next message, retrying every reattachDelay until ctx is done.
 func (r *receiver) Receive(ctx context.Context) (binding.Message, error) {
-	m, err := r.amqp.Receive(ctx)
-	if err != nil {
-		return nil, err
+	for {
+		r.mu.Lock()
+		link := r.amqp
+		r.mu.Unlock()
+		m, err := link.Receive(ctx)
+		if err == nil {
+			msg := NewMessage(m)
+			msg.replies = r.replies
+			return msg, nil
+		}
+		r.mu.Lock()
+		closed := r.closed
+		r.mu.Unlock()
+		if r.attach == nil || closed || ctx.Err() != nil || !isLinkError(err) {
+			return nil, err
+		}
+		if err := r.reattach(ctx, link); err != nil {
+			return nil, err
+		}
 	}
+}
 
-	return NewMessage(m), nil
+// reattach replaces the failed link, until it succeeds or ctx is done
+func (r *receiver) reattach(ctx context.Context, failed amqpReceiver) error {
+	_ = failed.Close(ctx)
+	for {
+		link, err := r.attach()
+		if err == nil {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			if r.closed {
+				_ = link.Close(ctx)
+				return io.EOF
+			}
+			r.amqp = link
+			return nil
+		}
+		r.mu.Lock()
+		closed := r.closed
+		r.mu.Unlock()
+		if closed {
+			return io.EOF
+		}
+		cecontext.LoggerFrom(ctx).Warnw("failed attaching the receiver link", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(reattachDelay):
+		}
+	}
 }
 
-func (r *receiver) Close(ctx context.Context) error { return r.amqp.Close(ctx) }
+func (r *receiver) Close(ctx context.Context) error {
+	if r.ownedReplies != nil {
+		_ = r.ownedReplies.Close(ctx)
+	}
+	r.mu.Lock()
+	link := r.amqp
+	r.closed = true
+	r.mu.Unlock()
+	return link.Close(ctx)
+}
 
 // Create a new Receiver which wraps an amqp.Receiver in a binding.Receiver
-func NewReceiver(amqp *amqp.Receiver) transport.Receiver {
-	return &receiver{amqp: amqp}
+func NewReceiver(amqp *amqp.Receiver, options ...ReceiverOptionFunc) transport.Receiver {
+	r := &receiver{amqp: amqp}
+	for _, o := range options {
+		o(r)
+	}
+	return r
 }
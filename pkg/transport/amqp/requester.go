@@ -0,0 +1,202 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"pack.ag/amqp"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	bindings "github.com/cloudevents/sdk-go/pkg/transport"
+)
+
+// DefaultRequestTimeout is the default time the Requester waits for a response,
+// when the context passed to Request has no deadline
+const DefaultRequestTimeout = 10 * time.Second
+
+// amqpReceiver is the part of *amqp.Receiver used by requester
+type amqpReceiver interface {
+	Receive(ctx context.Context) (*amqp.Message, error)
+	Address() string
+	Close(ctx context.Context) error
+}
+
+// requester implements binding.Requester.
+// The requests are sent with the reply-to address of a dynamic receiver link, attached on the first request,
+// and with a unique message-id and correlation-id. The responses are matched to the outstanding requests
+// with their correlation-id.
+type requester struct {
+	*sender
+	attach func() (amqpReceiver, error)
+
+	mu      sync.Mutex
+	replies amqpReceiver
+	pending map[string]*pendingRequest
+}
+
+// pendingRequest is a request waiting for its response.
+// If the reply link fails, err is set and reply is closed.
+type pendingRequest struct {
+	reply chan *amqp.Message
+	err   error
+}
+
+// Request sends the message and waits for the response,
+// until ctx is done or, if ctx has no deadline, for the request timeout.
+func (r *requester) Request(ctx context.Context, in binding.Message) (resp binding.Message, err error) {
+	defer func() { _ = in.Finish(err) }()
+
+	var amqpMessage amqp.Message
+	err = WriteAMQPMessage(ctx, in, &amqpMessage, r.transformers)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ctx.Deadline(); !ok && r.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.requestTimeout)
+		defer cancel()
+	}
+
+	id := uuid.New().String()
+	replyTo, p, err := r.register(id)
+	if err != nil {
+		err = bindings.NewUndelivered(TransportName, err)
+		return nil, err
+	}
+	defer r.unregister(id)
+
+	if amqpMessage.Properties == nil {
+		amqpMessage.Properties = &amqp.MessageProperties{}
+	}
+	amqpMessage.Properties.MessageID = id
+	amqpMessage.Properties.CorrelationID = id
+	amqpMessage.Properties.ReplyTo = replyTo
+
+	err = toResult(r.amqp.Send(ctx, &amqpMessage))
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case reply, ok := <-p.reply:
+		if !ok {
+			err = bindings.NewUndelivered(TransportName, p.err)
+			return nil, err
+		}
+		return NewMessage(reply), nil
+	case <-ctx.Done():
+		err = bindings.NewUndelivered(TransportName, ctx.Err())
+		return nil, err
+	}
+}
+
+// register adds the request id to the outstanding requests, attaching the reply link if needed,
+// and it returns the reply-to address.
+func (r *requester) register(id string) (string, *pendingRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.replies == nil {
+		replies, err := r.attach()
+		if err != nil {
+			return "", nil, err
+		}
+		if replies.Address() == "" {
+			_ = replies.Close(context.Background())
+			return "", nil, errors.New("no dynamic address assigned to the reply link")
+		}
+		r.replies = replies
+		go r.dispatch(replies)
+	}
+	p := &pendingRequest{reply: make(chan *amqp.Message, 1)}
+	r.pending[id] = p
+	return r.replies.Address(), p, nil
+}
+
+func (r *requester) unregister(id string) {
+	r.mu.Lock()
+	delete(r.pending, id)
+	r.mu.Unlock()
+}
+
+// dispatch delivers the responses received on the reply link to the outstanding requests.
+// When the reply link fails, the outstanding requests fail and the next request attaches a new reply link.
+func (r *requester) dispatch(replies amqpReceiver) {
+	for {
+		msg, err := replies.Receive(context.Background())
+		if err != nil {
+			r.detach(replies, err)
+			return
+		}
+		r.mu.Lock()
+		id := correlationID(msg)
+		p, ok := r.pending[id]
+		delete(r.pending, id)
+		r.mu.Unlock()
+		if ok {
+			p.reply <- msg
+		} else {
+			// Late response to a request which is not waiting anymore, discard it.
+			_ = msg.Accept()
+		}
+	}
+}
+
+func (r *requester) detach(replies amqpReceiver, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.replies != replies {
+		return
+	}
+	r.replies = nil
+	for id, p := range r.pending {
+		p.err = err
+		close(p.reply)
+		delete(r.pending, id)
+	}
+}
+
+func correlationID(msg *amqp.Message) string {
+	if msg.Properties == nil {
+		return ""
+	}
+	switch id := msg.Properties.CorrelationID.(type) {
+	case string:
+		return id
+	case []byte:
+		return string(id)
+	}
+	return ""
+}
+
+// Close closes the reply link, if attached, and the sender link.
+func (r *requester) Close(ctx context.Context) error {
+	r.mu.Lock()
+	replies := r.replies
+	r.mu.Unlock()
+	if replies != nil {
+		if err := replies.Close(ctx); err != nil {
+			return err
+		}
+	}
+	return r.sender.Close(ctx)
+}
+
+// Create a new Requester which wraps an amqp.Sender in a binding.Requester.
+// The responses are received on a dynamic receiver link of session.
+func NewRequester(session *amqp.Session, amqpSender *amqp.Sender, options ...SenderOptionFunc) bindings.Requester {
+	return newRequester(newSender(amqpSender, options...), func() (amqpReceiver, error) {
+		receiver, err := session.NewReceiver(amqp.LinkAddressDynamic())
+		if err != nil {
+			return nil, err
+		}
+		return receiver, nil
+	})
+}
+
+func newRequester(s *sender, attach func() (amqpReceiver, error)) *requester {
+	return &requester{sender: s, attach: attach, pending: make(map[string]*pendingRequest)}
+}
@@ -0,0 +1,174 @@
+package amqp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"pack.ag/amqp"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	bindings "github.com/cloudevents/sdk-go/pkg/transport"
+)
+
+// fakeLinks is a sender link echoing the requests to the reply link with their message-id as correlation-id,
+// unless the responder is mute
+type fakeLinks struct {
+	mu       sync.Mutex
+	sent     []*amqp.Message
+	replies  chan *amqp.Message
+	detached chan struct{}
+	attached int
+	mute     bool
+	// sending, if not nil, is signalled after each request is sent
+	sending chan struct{}
+}
+
+func newFakeLinks() *fakeLinks {
+	return &fakeLinks{replies: make(chan *amqp.Message, 10)}
+}
+
+func (l *fakeLinks) Send(ctx context.Context, msg *amqp.Message) error {
+	l.mu.Lock()
+	l.sent = append(l.sent, msg)
+	if !l.mute {
+		reply := *msg
+		props := *msg.Properties
+		props.CorrelationID, props.MessageID, props.ReplyTo = msg.Properties.MessageID, nil, ""
+		reply.Properties = &props
+		l.replies <- &reply
+	}
+	sending := l.sending
+	l.mu.Unlock()
+	if sending != nil {
+		sending <- struct{}{}
+	}
+	return nil
+}
+
+func (l *fakeLinks) Close(ctx context.Context) error { return nil }
+
+func (l *fakeLinks) attach() (amqpReceiver, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.attached++
+	l.detached = make(chan struct{})
+	return &fakeReceiver{links: l, detached: l.detached}, nil
+}
+
+// detach fails the attached reply link
+func (l *fakeLinks) detach() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	close(l.detached)
+}
+
+type fakeReceiver struct {
+	links    *fakeLinks
+	detached chan struct{}
+}
+
+func (r *fakeReceiver) Receive(ctx context.Context) (*amqp.Message, error) {
+	select {
+	case msg := <-r.links.replies:
+		return msg, nil
+	case <-r.detached:
+		return nil, amqp.ErrLinkClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (r *fakeReceiver) Address() string { return "reply-address" }
+
+func (r *fakeReceiver) Close(ctx context.Context) error { return nil }
+
+func TestRequest(t *testing.T) {
+	links := newFakeLinks()
+	r := newRequester(newSender(links), links.attach)
+
+	var wg sync.WaitGroup
+	events := test.Events()
+	errs := make(chan error, len(events))
+	for _, e := range events {
+		in := test.ExToStr(t, e)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := r.Request(binding.WithForceBinary(context.Background()), binding.EventMessage(in))
+			if err != nil {
+				errs <- err
+				return
+			}
+			out, err := binding.ToEvent(context.Background(), resp, nil)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if out.ID() != in.ID() {
+				errs <- fmt.Errorf("response to %s has ID %s", in.ID(), out.ID())
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 1, links.attached)
+	require.Len(t, links.sent, len(events))
+	for _, msg := range links.sent {
+		require.Equal(t, "reply-address", msg.Properties.ReplyTo)
+		require.NotNil(t, msg.Properties.MessageID)
+		require.Equal(t, msg.Properties.MessageID, msg.Properties.CorrelationID)
+	}
+	require.Empty(t, r.pending)
+}
+
+func TestRequestTimeout(t *testing.T) {
+	links := newFakeLinks()
+	links.mute = true
+	r := newRequester(newSender(links, WithRequestTimeout(10*time.Millisecond)), links.attach)
+
+	_, err := r.Request(context.Background(), binding.EventMessage(test.FullEvent()))
+	require.True(t, bindings.IsUndelivered(err))
+	require.Empty(t, r.pending)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	r = newRequester(newSender(links, WithRequestTimeout(0)), links.attach)
+	_, err = r.Request(ctx, binding.EventMessage(test.FullEvent()))
+	require.True(t, bindings.IsUndelivered(err))
+}
+
+func TestRequestReplyLinkDetached(t *testing.T) {
+	links := newFakeLinks()
+	links.mute = true
+	links.sending = make(chan struct{})
+	r := newRequester(newSender(links, WithRequestTimeout(0)), links.attach)
+
+	errs := make(chan error)
+	go func() {
+		_, err := r.Request(context.Background(), binding.EventMessage(test.FullEvent()))
+		errs <- err
+	}()
+	// The request is pending once it is sent
+	<-links.sending
+	links.detach()
+	err := <-errs
+	require.True(t, bindings.IsUndelivered(err))
+	require.Contains(t, err.Error(), amqp.ErrLinkClosed.Error())
+
+	// The next request attaches a new reply link
+	links.mu.Lock()
+	links.mute, links.sending = false, nil
+	links.mu.Unlock()
+	_, err = r.Request(context.Background(), binding.EventMessage(test.FullEvent()))
+	require.NoError(t, err)
+	require.Equal(t, 2, links.attached)
+}
@@ -2,6 +2,8 @@ package amqp
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	bindings "github.com/cloudevents/sdk-go/pkg/transport"
 	"pack.ag/amqp"
@@ -9,17 +11,25 @@ import (
 	"github.com/cloudevents/sdk-go/pkg/binding"
 )
 
+// amqpSender is the part of *amqp.Sender used by sender
+type amqpSender interface {
+	Send(ctx context.Context, msg *amqp.Message) error
+	Close(ctx context.Context) error
+}
+
 // sender wraps an amqp.Sender as a binding.Sender
 type sender struct {
-	amqp         *amqp.Sender
-	transformers binding.TransformerFactories
+	amqp           amqpSender
+	transformers   binding.TransformerFactories
+	requestTimeout time.Duration
 }
 
 func (s *sender) Send(ctx context.Context, in binding.Message) error {
 	var err error
 	defer func() { _ = in.Finish(err) }()
 	if m, ok := in.(*Message); ok { // Already an AMQP message.
-		return s.amqp.Send(ctx, m.AMQP)
+		err = toResult(s.amqp.Send(ctx, m.AMQP))
+		return err
 	}
 
 	var amqpMessage amqp.Message
@@ -28,14 +38,35 @@ func (s *sender) Send(ctx context.Context, in binding.Message) error {
 		return err
 	}
 
-	return s.amqp.Send(ctx, &amqpMessage)
+	err = toResult(s.amqp.Send(ctx, &amqpMessage))
+	return err
+}
+
+// toResult converts the error returned by the amqp.Sender to a transport.Result.
+// Rejected messages are returned as NACKs with the AMQP error attached.
+func toResult(err error) error {
+	if err == nil {
+		return nil
+	}
+	if amqpErr, ok := err.(*amqp.Error); ok {
+		if amqpErr == nil {
+			// Rejected without error description
+			return bindings.NewNACK(TransportName, 0, nil, errors.New("message rejected"))
+		}
+		return bindings.NewNACK(TransportName, 0, nil, amqpErr)
+	}
+	return bindings.NewUndelivered(TransportName, err)
 }
 
 func (s *sender) Close(ctx context.Context) error { return s.amqp.Close(ctx) }
 
 // Create a new Sender which wraps an amqp.Sender in a binding.Sender
 func NewSender(amqpSender *amqp.Sender, options ...SenderOptionFunc) bindings.Sender {
-	s := &sender{amqp: amqpSender, transformers: make(binding.TransformerFactories, 0)}
+	return newSender(amqpSender, options...)
+}
+
+func newSender(amqpSender amqpSender, options ...SenderOptionFunc) *sender {
+	s := &sender{amqp: amqpSender, transformers: make(binding.TransformerFactories, 0), requestTimeout: DefaultRequestTimeout}
 	for _, o := range options {
 		o(s)
 	}
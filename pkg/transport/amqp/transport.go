@@ -27,11 +27,19 @@ type Transport struct {
 	sessionOpts      []amqp.SessionOption
 	senderLinkOpts   []amqp.LinkOption
 	receiverLinkOpts []amqp.LinkOption
+	senderOpts       []SenderOptionFunc
 
 	// Encoding
 	Encoding Encoding
 
 	// AMQP
+	// Client, Session and Sender are the connection and the sender link to Node opened by New.
+	// The messages are sent to Node, or to the address set in the context with cecontext.WithTopic
+	// or cecontext.WithTarget, on sender links created on demand. Sender links are created again
+	// when they are detached and the connection is dialed again when it fails, together with the
+	// receiver and reply links: Client, Session and Sender are not updated then.
+	// At most 100 sender links, including the links to the reply-to addresses, are kept attached:
+	// the least recently used one is closed when a link to a new address is created.
 	Client  *amqp.Client
 	Session *amqp.Session
 	Sender  *amqp.Sender
@@ -39,6 +47,8 @@ type Transport struct {
 
 	// Receiver
 	Receiver transport.Receiver
+
+	links *senderLinks
 }
 
 // New creates a new amqp transport.
@@ -54,58 +64,62 @@ func New(server, queue string, opts ...Option) (*Transport, error) {
 		return nil, err
 	}
 
-	client, err := amqp.Dial(server, t.connOpts...)
-	if err != nil {
-		return nil, err
-	}
-	t.Client = client
-
-	// Open a session
-	session, err := client.NewSession(t.sessionOpts...)
+	// Open a connection and a session
+	conn, err := dialSession(server, t.connOpts, t.sessionOpts, t.senderLinkOpts)
 	if err != nil {
-		_ = client.Close()
 		return nil, err
 	}
-	t.Session = session
-
-	t.senderLinkOpts = append(t.senderLinkOpts, amqp.LinkTargetAddress(queue))
+	t.Client = conn.client
+	t.Session = conn.session
 
 	// Create a sender
-	sender, err := session.NewSender(t.senderLinkOpts...)
+	sender, err := conn.newSender(queue)
 	if err != nil {
-		_ = client.Close()
-		_ = session.Close(context.Background())
+		_ = conn.close()
 		return nil, err
 	}
-	// TODO: in the future we might have more than one sender.
-	t.BindingTransport.Sender, t.BindingTransport.SenderContextDecorators = t.applyEncoding(sender)
+	t.Sender = sender.(*amqp.Sender)
+
+	t.links = newSenderLinks(queue, func() (linkSession, error) {
+		return dialSession(server, t.connOpts, t.sessionOpts, t.senderLinkOpts)
+	})
+	ready := make(chan struct{})
+	close(ready)
+	t.links.conn = conn
+	t.links.links[queue] = &pooledLink{conn: conn, link: sender, ready: ready}
+
+	t.BindingTransport.Requester, t.BindingTransport.SenderContextDecorators = t.applyEncoding()
+	t.BindingTransport.Sender = t.BindingTransport.Requester
 	return t, nil
 }
 
-func (t *Transport) applyEncoding(amqpSender *amqp.Sender) (transport.Sender, []func(context.Context) context.Context) {
+func (t *Transport) applyEncoding() (transport.Requester, []func(context.Context) context.Context) {
 	switch t.Encoding {
 	case BinaryV03:
-		return NewSender(
-			amqpSender,
+		return t.newRequester(
 			WithTransformer(transformer.Version(spec.V03)),
 		), []func(context.Context) context.Context{binding.WithForceBinary}
 	case BinaryV1:
-		return NewSender(
-			amqpSender,
+		return t.newRequester(
 			WithTransformer(transformer.Version(spec.V1)),
 		), []func(context.Context) context.Context{binding.WithForceBinary}
 	case StructuredV03:
-		return NewSender(
-			amqpSender,
+		return t.newRequester(
 			WithTransformer(transformer.Version(spec.V03)),
 		), []func(context.Context) context.Context{binding.WithForceStructured}
 	case StructuredV1:
-		return NewSender(
-			amqpSender,
+		return t.newRequester(
 			WithTransformer(transformer.Version(spec.V1)),
 		), []func(context.Context) context.Context{binding.WithForceStructured}
 	}
-	return NewSender(amqpSender), []func(context.Context) context.Context{}
+	return t.newRequester(), []func(context.Context) context.Context{}
+}
+
+// newRequester creates a requester sending with the sender links of the transport
+func (t *Transport) newRequester(options ...SenderOptionFunc) transport.Requester {
+	return newRequester(newSender(t.links, append(options, t.senderOpts...)...), func() (amqpReceiver, error) {
+		return t.links.newReceiver(amqp.LinkAddressDynamic())
+	})
 }
 
 func (t *Transport) applyOptions(opts ...Option) error {
@@ -124,11 +138,14 @@ func (t *Transport) StartReceiver(ctx context.Context) error {
 	logger.Info("StartReceiver on ", t.Node)
 
 	t.receiverLinkOpts = append(t.receiverLinkOpts, amqp.LinkSourceAddress(t.Node))
-	receiver, err := t.Session.NewReceiver(t.receiverLinkOpts...)
+	attach := func() (amqpReceiver, error) {
+		return t.links.newReceiver(t.receiverLinkOpts...)
+	}
+	link, err := attach()
 	if err != nil {
 		return err
 	}
-	t.BindingTransport.Receiver = NewReceiver(receiver)
+	t.BindingTransport.Receiver = &receiver{amqp: link, attach: attach, replies: t.links}
 	return t.BindingTransport.StartReceiver(ctx)
 }
 
@@ -138,5 +155,5 @@ func (t *Transport) HasTracePropagation() bool {
 }
 
 func (t *Transport) Close() error {
-	return t.Client.Close()
+	return t.links.Close(context.Background())
 }
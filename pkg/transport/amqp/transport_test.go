@@ -108,3 +108,25 @@ func TestWithEncoding(t *testing.T) {
 		AssertEventEquals(t, exurl(e), got.(event.Event))
 	})
 }
+
+// responder responds to the requests with the request event
+type responder struct{}
+
+func (responder) Delivery(_ context.Context, e event.Event, resp *event.EventResponse) error {
+	resp.RespondWith(200, &e)
+	return nil
+}
+
+func TestRequest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, r := testTransport(t), testTransport(t)
+	defer func() { _ = s.Close(); _ = r.Close() }()
+	r.SetDelivery(responder{})
+	go func() { _ = r.StartReceiver(ctx) }()
+	EachEvent(t, Events(), func(t *testing.T, e event.Event) {
+		got, err := s.Request(ctx, e)
+		require.NoError(t, err)
+		AssertEventEquals(t, exurl(e), *got)
+	})
+}
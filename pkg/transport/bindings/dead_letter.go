@@ -0,0 +1,50 @@
+package bindings
+
+import (
+	"context"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/buffering"
+	"github.com/cloudevents/sdk-go/pkg/binding/transformer"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+const (
+	// DeadLetterReasonExtension is the extension holding the error returned by the last delivery attempt
+	DeadLetterReasonExtension = "deadletterreason"
+	// DeadLetterAttemptsExtension is the extension holding the number of delivery attempts
+	DeadLetterAttemptsExtension = "deadletterattempts"
+	// DeadLetterSourceExtension is the extension holding the source of the dead lettered event
+	DeadLetterSourceExtension = "deadlettersource"
+)
+
+// deliver delivers e to the handler, retrying as configured by DeliveryRetryParams.
+// Returns the number of attempts and the error returned by the last attempt.
+func (t *BindingTransport) deliver(ctx context.Context, e event.Event, eventResp *event.EventResponse) (int, error) {
+	params := t.DeliveryRetryParams
+	attempts := params.Attempts()
+	for tries := 1; ; tries++ {
+		*eventResp = event.EventResponse{}
+		err := t.handler.Delivery(ctx, e, eventResp)
+		if err == nil || tries >= attempts {
+			return tries, err
+		}
+		if params.Backoff(ctx, tries) != nil {
+			return tries, err
+		}
+	}
+}
+
+// deadLetter forwards the message m, which failed delivery with cause after the provided attempts,
+// to the DeadLetterSender. The message is forwarded unchanged, except for the dead letter extensions.
+func (t *BindingTransport) deadLetter(ctx context.Context, m binding.Message, source string, attempts int, cause error) error {
+	dl, err := buffering.CopyMessage(ctx, m, binding.TransformerFactories{
+		transformer.AddExtension(DeadLetterReasonExtension, cause.Error()),
+		transformer.AddExtension(DeadLetterAttemptsExtension, int32(attempts)),
+		transformer.AddExtension(DeadLetterSourceExtension, source),
+	})
+	if err != nil {
+		return err
+	}
+	return t.DeadLetterSender.Send(ctx, dl)
+}
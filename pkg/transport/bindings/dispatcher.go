@@ -0,0 +1,128 @@
+package bindings
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+)
+
+// OrderedMessage is implemented by the messages which must be handled in order
+// with the other messages sharing the same ordering key, e.g. the messages consumed
+// from the same Kafka partition.
+type OrderedMessage interface {
+	binding.Message
+
+	// OrderingKey returns the key which identifies the ordered sequence of messages
+	OrderingKey() string
+}
+
+// orderingKeyOf walks through the MessageWrapper chain looking for an OrderedMessage
+func orderingKeyOf(m binding.Message) (string, bool) {
+	for m != nil {
+		if om, ok := m.(OrderedMessage); ok {
+			return om.OrderingKey(), true
+		}
+		if mw, ok := m.(binding.MessageWrapper); ok {
+			m = mw.GetWrappedMessage()
+		} else {
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// dispatcher handles the messages with at most len(slots) messages in flight.
+// When ordered is true, the messages with the same ordering key are handled one at a time, in order.
+type dispatcher struct {
+	handle  func(context.Context, binding.Message) error
+	ordered bool
+
+	slots chan struct{}
+	wg    sync.WaitGroup
+
+	mu     sync.Mutex
+	queues map[string][]binding.Message // Pending messages of the keys being handled
+
+	errs chan error
+}
+
+func newDispatcher(concurrency int, ordered bool, handle func(context.Context, binding.Message) error) *dispatcher {
+	return &dispatcher{
+		handle:  handle,
+		ordered: ordered,
+		slots:   make(chan struct{}, concurrency),
+		queues:  make(map[string][]binding.Message),
+		errs:    make(chan error, 1),
+	}
+}
+
+// dispatch blocks until a slot is available, then it handles m in a new goroutine,
+// or it enqueues m if another message with the same ordering key is being handled.
+func (d *dispatcher) dispatch(ctx context.Context, m binding.Message) {
+	d.slots <- struct{}{}
+	d.wg.Add(1)
+
+	key, ok := "", false
+	if d.ordered {
+		key, ok = orderingKeyOf(m)
+	}
+	if !ok {
+		go d.run(ctx, m)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if q, busy := d.queues[key]; busy {
+		d.queues[key] = append(q, m)
+		return
+	}
+	d.queues[key] = nil
+	go d.runOrdered(ctx, key, m)
+}
+
+func (d *dispatcher) run(ctx context.Context, m binding.Message) {
+	defer func() {
+		<-d.slots
+		d.wg.Done()
+	}()
+	if err := d.handle(ctx, m); err != nil {
+		select {
+		case d.errs <- err:
+		default: // Keep only the first error
+		}
+	}
+}
+
+// runOrdered handles m and then the messages enqueued with the same key, until the queue is empty
+func (d *dispatcher) runOrdered(ctx context.Context, key string, m binding.Message) {
+	for {
+		d.run(ctx, m)
+
+		d.mu.Lock()
+		q := d.queues[key]
+		if len(q) == 0 {
+			delete(d.queues, key)
+			d.mu.Unlock()
+			return
+		}
+		m, d.queues[key] = q[0], q[1:]
+		d.mu.Unlock()
+	}
+}
+
+// err returns the first error returned by the handler, if any
+func (d *dispatcher) err() error {
+	select {
+	case err := <-d.errs:
+		return err
+	default:
+		return nil
+	}
+}
+
+// drain waits for the in flight messages to be handled
+func (d *dispatcher) drain() {
+	d.wg.Wait()
+}
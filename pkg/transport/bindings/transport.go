@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/buffering"
 	bindings "github.com/cloudevents/sdk-go/pkg/transport"
 
 	"go.uber.org/zap"
@@ -23,7 +24,28 @@ type BindingTransport struct {
 	Receiver  bindings.Receiver
 	// SenderContextDecorators can be used to decorate the context passed to the Sender.Send() method
 	SenderContextDecorators []func(context.Context) context.Context
-	handler                 transport.Delivery
+	// Concurrency is the maximum number of messages handled in parallel by StartReceiver.
+	// Values lower or equal to 1 handle the messages serially.
+	// Unless OrderedByKey is set, the messages are finished out of order: the Kafka receiver marks
+	// the offset of each finished message, so it can commit the offset of a message while the previous
+	// messages of the same partition are still handled, and they are lost if the consumer stops.
+	Concurrency int
+	// OrderedByKey makes StartReceiver handle one at a time and in order the messages
+	// implementing OrderedMessage with the same ordering key, e.g. the messages of the same Kafka partition.
+	// It's meaningful only when Concurrency is greater than 1.
+	OrderedByKey bool
+	// DeliveryRetryParams configures how many times and with which backoff the delivery of a message
+	// to the handler is attempted, before giving up. By default, the delivery is attempted once.
+	DeliveryRetryParams cecontext.RetryParams
+	// DeadLetterSender, if set, receives the messages whose delivery failed after all the attempts.
+	// The original message is forwarded with the extensions DeadLetterReasonExtension,
+	// DeadLetterAttemptsExtension and DeadLetterSourceExtension.
+	// The received message is finished successfully when it's forwarded to the DeadLetterSender.
+	DeadLetterSender bindings.Sender
+	// ReceiverTransformers are applied to the received messages and responses while converting them to events,
+	// e.g. to resolve the data referenced by the dataref extension.
+	ReceiverTransformers binding.TransformerFactories
+	handler              transport.Delivery
 }
 
 var _ transport.Transport = (*BindingTransport)(nil) // Conforms to the interface
@@ -63,13 +85,15 @@ func (t *BindingTransport) Request(ctx context.Context, e event.Event) (*event.E
 	// If provided a requester, use it to do request/response.
 	var resp *event.Event
 	msg, err := t.Requester.Request(ctx, binding.EventMessage(e))
-	defer func() {
-		if err := msg.Finish(err); err != nil {
-			cecontext.LoggerFrom(ctx).Warnw("failed calling message.Finish", zap.Error(err))
-		}
-	}()
-	if err == nil {
-		if rs, err := binding.ToEvent(ctx, msg, nil); err != nil {
+	if msg != nil {
+		defer func() {
+			if err := msg.Finish(err); err != nil {
+				cecontext.LoggerFrom(ctx).Warnw("failed calling message.Finish", zap.Error(err))
+			}
+		}()
+	}
+	if err == nil && msg != nil {
+		if rs, err := binding.ToEvent(ctx, msg, t.ReceiverTransformers); err != nil {
 			cecontext.LoggerFrom(ctx).Warnw("failed calling ToEvent", zap.Error(err), zap.Any("resp", msg))
 		} else {
 			resp = rs
@@ -82,7 +106,14 @@ func (t *BindingTransport) SetDelivery(r transport.Delivery) {
 	t.handler = r
 }
 
+// StartReceiver receives the messages from the Receiver and delivers them to the handler,
+// handling at most Concurrency messages in parallel.
+// When Receiver.Receive returns an error, e.g. because ctx is done, StartReceiver stops receiving
+// and it waits for the in flight messages to be handled before returning.
 func (t *BindingTransport) StartReceiver(ctx context.Context) error {
+	if t.Concurrency > 1 {
+		return t.startConcurrentReceiver(ctx)
+	}
 	for {
 		msg, err := t.Receiver.Receive(ctx)
 		if err == io.EOF { // Normal close
@@ -96,6 +127,23 @@ func (t *BindingTransport) StartReceiver(ctx context.Context) error {
 	}
 }
 
+func (t *BindingTransport) startConcurrentReceiver(ctx context.Context) error {
+	d := newDispatcher(t.Concurrency, t.OrderedByKey, t.handle)
+	defer d.drain()
+	for {
+		if err := d.err(); err != nil {
+			return err
+		}
+		msg, err := t.Receiver.Receive(ctx)
+		if err == io.EOF { // Normal close
+			return nil
+		} else if err != nil {
+			return err
+		}
+		d.dispatch(ctx, msg)
+	}
+}
+
 func (t *BindingTransport) handle(ctx context.Context, m binding.Message) (err error) {
 	defer func() {
 		if err2 := m.Finish(err); err2 == nil {
@@ -107,12 +155,28 @@ func (t *BindingTransport) handle(ctx context.Context, m binding.Message) (err e
 		return
 	}
 
-	e, err := binding.ToEvent(ctx, m, nil)
+	if m.ReadEncoding() == binding.EncodingBatch {
+		return t.handleBatch(ctx, m)
+	}
+
+	// Keep a copy of the original message, to forward it to the dead letter sender
+	original := binding.Message(m)
+	if t.DeadLetterSender != nil {
+		if original, err = buffering.CopyMessage(ctx, m, nil); err != nil {
+			return err
+		}
+		defer func() { _ = original.Finish(nil) }()
+	}
+
+	e, err := binding.ToEvent(ctx, original, t.ReceiverTransformers)
 	if err != nil {
 		return err
 	}
 	eventResp := event.EventResponse{}
-	if err := t.handler.Delivery(ctx, *e, &eventResp); err != nil {
+	if attempts, err := t.deliver(ctx, *e, &eventResp); err != nil {
+		if t.DeadLetterSender != nil {
+			return t.deadLetter(ctx, original, e.Source(), attempts, err)
+		}
 		return err
 	}
 
@@ -126,4 +190,25 @@ func (t *BindingTransport) handle(ctx context.Context, m binding.Message) (err e
 	return nil
 }
 
+// handleBatch delivers each event of the batch to the handler.
+// Response events are not supported for batches and they are discarded.
+// Events failing delivery are forwarded one by one to the dead letter sender, if any.
+func (t *BindingTransport) handleBatch(ctx context.Context, m binding.Message) error {
+	events, err := binding.ToEvents(ctx, m, t.ReceiverTransformers)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		if attempts, err := t.deliver(ctx, e, &event.EventResponse{}); err != nil {
+			if t.DeadLetterSender == nil {
+				return err
+			}
+			if err := t.deadLetter(ctx, binding.EventMessage(e), e.Source(), attempts, err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (t *BindingTransport) HasTracePropagation() bool { return false } // TODO
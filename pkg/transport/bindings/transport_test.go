@@ -2,7 +2,11 @@ package bindings_test
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	bindings2 "github.com/cloudevents/sdk-go/pkg/transport/bindings"
 
@@ -11,6 +15,7 @@ import (
 	"github.com/cloudevents/sdk-go/pkg/binding"
 	"github.com/cloudevents/sdk-go/pkg/binding/test"
 	client "github.com/cloudevents/sdk-go/pkg/client"
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
 	"github.com/cloudevents/sdk-go/pkg/event"
 )
 
@@ -52,3 +57,246 @@ func TestTransportReceive(t *testing.T) {
 
 	test.AssertEventEquals(t, ev, result)
 }
+
+func TestTransportReceiveBatch(t *testing.T) {
+	messageChannel := make(chan binding.Message, 1)
+	eventReceivedChannel := make(chan event.Event, 2)
+	transport := bindings2.NewSendingTransport(binding.ChanSender(messageChannel), binding.ChanReceiver(messageChannel), nil)
+	ev1 := test.MinEvent()
+	ev2 := test.FullEvent()
+
+	c, err := client.New(transport)
+	require.NoError(t, err)
+
+	messageChannel <- binding.EventBatchMessage{ev1, ev2}
+
+	go func() {
+		err = c.StartReceiver(context.Background(), func(event event.Event) {
+			eventReceivedChannel <- event
+		})
+		require.NoError(t, err)
+	}()
+
+	test.AssertEventEquals(t, ev1, <-eventReceivedChannel)
+	test.AssertEventEquals(t, ev2, <-eventReceivedChannel)
+}
+
+func TestTransportReceiveConcurrent(t *testing.T) {
+	const concurrency = 3
+	messageChannel := make(chan binding.Message, concurrency)
+	transport := bindings2.NewSendingTransport(binding.ChanSender(messageChannel), binding.ChanReceiver(messageChannel), nil)
+	transport.Concurrency = concurrency
+
+	c, err := client.New(transport)
+	require.NoError(t, err)
+
+	for i := 0; i < concurrency; i++ {
+		messageChannel <- binding.EventMessage(test.MinEvent())
+	}
+
+	// Every handler waits for all the others to start
+	var started sync.WaitGroup
+	started.Add(concurrency)
+	allStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = c.StartReceiver(ctx, func(event event.Event) {
+			started.Done()
+			<-allStarted
+		})
+	}()
+
+	select {
+	case <-allStarted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("messages were not handled concurrently")
+	}
+}
+
+type orderedMessage struct {
+	binding.Message
+	key string
+}
+
+func (m orderedMessage) OrderingKey() string { return m.key }
+
+func (m orderedMessage) GetWrappedMessage() binding.Message { return m.Message }
+
+func TestTransportReceiveOrderedByKey(t *testing.T) {
+	const n = 20
+	keys := []string{"a", "b"}
+	messageChannel := make(chan binding.Message, len(keys)*n)
+	transport := bindings2.NewSendingTransport(binding.ChanSender(messageChannel), binding.ChanReceiver(messageChannel), nil)
+	transport.Concurrency = 4
+	transport.OrderedByKey = true
+
+	c, err := client.New(transport)
+	require.NoError(t, err)
+
+	for i := 0; i < n; i++ {
+		for _, key := range keys {
+			ev := test.MinEvent()
+			ev.SetID(strconv.Itoa(i))
+			ev.SetExtension("key", key)
+			messageChannel <- orderedMessage{Message: binding.EventMessage(ev), key: key}
+		}
+	}
+	close(messageChannel)
+
+	var mu sync.Mutex
+	received := make(map[string][]string)
+	inFlight := make(map[string]bool)
+	concurrent := 0
+	err = c.StartReceiver(context.Background(), func(event event.Event) {
+		key := event.Extensions()["key"].(string)
+		mu.Lock()
+		if inFlight[key] {
+			concurrent++
+		}
+		inFlight[key] = true
+		received[key] = append(received[key], event.ID())
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		inFlight[key] = false
+		mu.Unlock()
+	})
+	require.NoError(t, err)
+
+	require.Zero(t, concurrent, "messages with the same key handled concurrently")
+	for _, key := range keys {
+		require.Len(t, received[key], n)
+		for i, id := range received[key] {
+			require.Equal(t, strconv.Itoa(i), id)
+		}
+	}
+}
+
+func TestTransportReceiveDrain(t *testing.T) {
+	messageChannel := make(chan binding.Message, 1)
+	transport := bindings2.NewSendingTransport(binding.ChanSender(messageChannel), binding.ChanReceiver(messageChannel), nil)
+	transport.Concurrency = 2
+
+	c, err := client.New(transport)
+	require.NoError(t, err)
+
+	messageChannel <- binding.EventMessage(test.MinEvent())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handled := make(chan struct{})
+	returned := make(chan error)
+	go func() {
+		returned <- c.StartReceiver(ctx, func(event event.Event) {
+			close(started)
+			<-release
+			close(handled)
+		})
+	}()
+
+	<-started
+	cancel()
+	select {
+	case <-returned:
+		t.Fatal("StartReceiver returned before draining the in flight messages")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(release)
+	require.Equal(t, context.Canceled, <-returned)
+	<-handled
+}
+
+// senderFunc converts the sent messages to events before finishing them
+type senderFunc func(binding.Encoding, event.Event)
+
+func (f senderFunc) Send(ctx context.Context, m binding.Message) error {
+	e, err := binding.ToEvent(ctx, m, nil)
+	if err != nil {
+		return err
+	}
+	f(m.ReadEncoding(), *e)
+	return m.Finish(nil)
+}
+
+func TestTransportDeadLetter(t *testing.T) {
+	type deadLetter struct {
+		encoding binding.Encoding
+		event    event.Event
+	}
+	messageChannel := make(chan binding.Message, 1)
+	deadLetterChannel := make(chan deadLetter, 1)
+	transport := bindings2.NewSendingTransport(binding.ChanSender(messageChannel), binding.ChanReceiver(messageChannel), nil)
+	transport.DeliveryRetryParams = cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Millisecond, MaxTries: 3}
+	transport.DeadLetterSender = senderFunc(func(enc binding.Encoding, e event.Event) {
+		deadLetterChannel <- deadLetter{encoding: enc, event: e}
+	})
+	ev := test.FullEvent()
+
+	c, err := client.New(transport)
+	require.NoError(t, err)
+
+	finished := make(chan error, 1)
+	messageChannel <- binding.WithFinish(test.MustCreateMockBinaryMessage(ev), func(err error) { finished <- err })
+
+	var mu sync.Mutex
+	attempts := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = c.StartReceiver(ctx, func(event event.Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+			attempts++
+			return errors.New("handler failure")
+		})
+	}()
+
+	out := <-deadLetterChannel
+	require.NoError(t, <-finished)
+	mu.Lock()
+	require.Equal(t, 3, attempts)
+	mu.Unlock()
+
+	require.Equal(t, binding.EncodingBinary, out.encoding)
+	want := test.CopyEventContext(ev)
+	want.SetExtension(bindings2.DeadLetterReasonExtension, "handler failure")
+	want.SetExtension(bindings2.DeadLetterAttemptsExtension, 3)
+	want.SetExtension(bindings2.DeadLetterSourceExtension, ev.Source())
+	test.AssertEventEquals(t, test.ExToStr(t, want), test.ExToStr(t, out.event))
+}
+
+func TestTransportDeliveryRetry(t *testing.T) {
+	messageChannel := make(chan binding.Message, 1)
+	deadLetterChannel := make(chan binding.Message, 1)
+	transport := bindings2.NewSendingTransport(binding.ChanSender(messageChannel), binding.ChanReceiver(messageChannel), nil)
+	transport.DeliveryRetryParams = cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Millisecond, MaxTries: 3}
+	transport.DeadLetterSender = binding.ChanSender(deadLetterChannel)
+
+	c, err := client.New(transport)
+	require.NoError(t, err)
+
+	messageChannel <- binding.EventMessage(test.MinEvent())
+	close(messageChannel)
+
+	attempts := 0
+	err = c.StartReceiver(context.Background(), func(event event.Event) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("transient failure")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+	require.Empty(t, deadLetterChannel)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/compression"
 	"github.com/cloudevents/sdk-go/pkg/binding/format"
 	"github.com/cloudevents/sdk-go/pkg/binding/spec"
 )
@@ -17,6 +18,8 @@ var specs = spec.WithPrefix(prefix)
 
 const ContentType = "Content-Type"
 const ContentLength = "Content-Length"
+const ContentEncoding = "Content-Encoding"
+const AcceptEncoding = "Accept-Encoding"
 
 // Message holds the Header and Body of a HTTP Request or Response.
 // The Message instance *must* be constructed from NewMessage function.
@@ -34,13 +37,37 @@ type Message struct {
 // Check if http.Message implements binding.Message
 var _ binding.Message = (*Message)(nil)
 
+// Check if http.Message implements binding.BatchMessage
+var _ binding.BatchMessage = (*Message)(nil)
+
 // NewMessage returns a binding.Message with header and data.
+// If the body is compressed with a codec registered in the compression package, as declared by the Content-Encoding
+// header, the body is decompressed while reading it and the Content-Encoding and Content-Length headers are removed.
+// Reading more than compression.DefaultMaxSize decompressed bytes fails with compression.ErrTooLarge.
+// If the codec is not registered, the encoding of the message is binding.EncodingUnknown.
 // The returned binding.Message *cannot* be read several times. In order to read it more times, buffer it using binding/buffering methods
 func NewMessage(header nethttp.Header, body io.ReadCloser) *Message {
+	return newMessage(header, body, compression.DefaultMaxSize)
+}
+
+// newMessage returns a binding.Message with header and data, see NewMessage,
+// failing with compression.ErrTooLarge after maxDecompressedSize decompressed bytes.
+func newMessage(header nethttp.Header, body io.ReadCloser, maxDecompressedSize int64) *Message {
 	m := Message{Header: header}
 	if body != nil {
 		m.BodyReader = body
 	}
+	if contentEncoding := header.Get(ContentEncoding); contentEncoding != "" && contentEncoding != "identity" {
+		codec := compression.Lookup(contentEncoding)
+		if codec == nil {
+			return &m
+		}
+		if body != nil {
+			m.BodyReader = &decompressingReader{codec: codec, body: body, maxSize: maxDecompressedSize}
+		}
+		header.Del(ContentEncoding)
+		header.Del(ContentLength)
+	}
 	if m.format = format.Lookup(header.Get(ContentType)); m.format == nil {
 		m.version = specs.Version(m.Header.Get(specs.PrefixedSpecVersionName()))
 	}
@@ -59,10 +86,42 @@ func NewMessageFromHttpResponse(req *nethttp.Response) *Message {
 	return NewMessage(req.Header, req.Body)
 }
 
+// decompressingReader decompresses the body with codec, creating the reader of codec on the first Read.
+// Reading more than maxSize decompressed bytes fails with compression.ErrTooLarge.
+type decompressingReader struct {
+	codec   compression.Codec
+	body    io.ReadCloser
+	maxSize int64
+	reader  io.ReadCloser
+	limited io.Reader
+}
+
+func (r *decompressingReader) Read(p []byte) (int, error) {
+	if r.reader == nil {
+		reader, err := r.codec.NewReader(r.body)
+		if err != nil {
+			return 0, err
+		}
+		r.reader = reader
+		r.limited = compression.LimitReader(reader, r.maxSize)
+	}
+	return r.limited.Read(p)
+}
+
+func (r *decompressingReader) Close() error {
+	if r.reader != nil {
+		_ = r.reader.Close()
+	}
+	return r.body.Close()
+}
+
 func (m *Message) ReadEncoding() binding.Encoding {
 	if m.version != nil {
 		return binding.EncodingBinary
 	}
+	if _, ok := m.format.(format.BatchFormat); ok {
+		return binding.EncodingBatch
+	}
 	if m.format != nil {
 		return binding.EncodingStructured
 	}
@@ -70,13 +129,20 @@ func (m *Message) ReadEncoding() binding.Encoding {
 }
 
 func (m *Message) ReadStructured(ctx context.Context, encoder binding.StructuredWriter) error {
-	if m.format == nil {
+	if _, ok := m.format.(format.BatchFormat); ok || m.format == nil {
 		return binding.ErrNotStructured
 	} else {
 		return encoder.SetStructuredEvent(ctx, m.format, m.BodyReader)
 	}
 }
 
+func (m *Message) ReadBatch(ctx context.Context, encoder binding.BatchWriter) error {
+	if f, ok := m.format.(format.BatchFormat); ok {
+		return encoder.SetBatchEvents(ctx, f, m.BodyReader)
+	}
+	return binding.ErrNotBatch
+}
+
 func (m *Message) ReadBinary(ctx context.Context, encoder binding.BinaryWriter) error {
 	if m.version == nil {
 		return binding.ErrNotBinary
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/compression"
 	"github.com/cloudevents/sdk-go/pkg/binding/test"
 	"github.com/cloudevents/sdk-go/pkg/event"
 )
@@ -57,3 +58,44 @@ func TestNewMessageUnknown(t *testing.T) {
 		require.Equal(t, binding.EncodingUnknown, got.ReadEncoding())
 	})
 }
+
+func TestNewMessageCompressed(t *testing.T) {
+	for _, ctx := range []context.Context{binding.WithForceBinary(context.TODO()), binding.WithForceStructured(context.TODO())} {
+		eventIn := test.FullEvent()
+		req := httptest.NewRequest("POST", "http://localhost", nil)
+		require.NoError(t, WriteHttpRequest(ctx, binding.EventMessage(eventIn), req, nil))
+		require.NoError(t, compressRequest(req, compression.Gzip))
+		require.Equal(t, "gzip", req.Header.Get(ContentEncoding))
+
+		got := NewMessageFromHttpRequest(req)
+		require.Empty(t, got.Header.Get(ContentEncoding))
+		gotEvent, err := binding.ToEvent(ctx, got, nil)
+		require.NoError(t, err)
+		test.AssertEventEquals(t, test.ExToStr(t, eventIn), test.ExToStr(t, *gotEvent))
+	}
+
+	req := httptest.NewRequest("POST", "http://localhost", bytes.NewReader([]byte("data")))
+	req.Header.Set(ContentType, "text/plain")
+	req.Header.Set(ContentEncoding, "unknown")
+	req.Header.Set(prefix+"specversion", "1.0")
+	got := NewMessageFromHttpRequest(req)
+	require.Equal(t, binding.EncodingUnknown, got.ReadEncoding())
+}
+
+func TestNewMessageBatch(t *testing.T) {
+	events := test.Events()
+	req := httptest.NewRequest("POST", "http://localhost", nil)
+	require.NoError(t, WriteHttpRequest(context.TODO(), binding.EventBatchMessage(events), req, binding.TransformerFactories{}))
+	require.Equal(t, event.ApplicationCloudEventsBatchJSON, req.Header.Get(ContentType))
+
+	got := NewMessageFromHttpRequest(req)
+	require.Equal(t, binding.EncodingBatch, got.ReadEncoding())
+	require.Equal(t, binding.ErrNotStructured, got.ReadStructured(context.TODO(), &test.MockStructuredMessage{}))
+
+	gotEvents, err := binding.ToEvents(context.TODO(), got, nil)
+	require.NoError(t, err)
+	require.Len(t, gotEvents, len(events))
+	for i := range events {
+		test.AssertEventEquals(t, test.ExToStr(t, events[i]), test.ExToStr(t, gotEvents[i]))
+	}
+}
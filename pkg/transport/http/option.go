@@ -1,6 +1,10 @@
 package http
 
-import "github.com/cloudevents/sdk-go/pkg/binding"
+import (
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/compression"
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
+)
 
 // http.Sender options
 type SenderOptionFunc func(sender *Sender)
@@ -11,3 +15,50 @@ func WithTransformer(transformer binding.TransformerFactory) SenderOptionFunc {
 		sender.transformers = append(sender.transformers, transformer)
 	}
 }
+
+// Set the default retry params, which Sender uses when a request fails with a network error or with a retryable status code.
+// The retry params set in the context passed to Send or Request, through context.WithRetryParams, take precedence.
+// The Retry-After header of the responses delays the retry, up to the MaxPeriod of the params.
+func WithRetryParams(params cecontext.RetryParams) SenderOptionFunc {
+	return func(sender *Sender) {
+		sender.retryParams = params
+	}
+}
+
+// Set the response status codes which make the Sender retry the request. Defaults to DefaultRetryableStatusCodes
+func WithRetryableStatusCodes(codes ...int) SenderOptionFunc {
+	return func(sender *Sender) {
+		sender.retryableStatusCodes = make(map[int]bool, len(codes))
+		for _, c := range codes {
+			sender.retryableStatusCodes[c] = true
+		}
+	}
+}
+
+// Compress the request bodies with codec, setting the Content-Encoding header.
+// The Sender also accepts responses compressed with the codecs registered in the compression package,
+// listing them in the Accept-Encoding header.
+func WithCompression(codec compression.Codec) SenderOptionFunc {
+	return func(sender *Sender) {
+		sender.compression = codec
+	}
+}
+
+// http.Receiver options
+type ReceiverOptionFunc func(receiver *Receiver)
+
+// Compress the responses with the first codec registered in the compression package
+// which is accepted by the request, through the Accept-Encoding header.
+func WithResponseCompression() ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.compressResponses = true
+	}
+}
+
+// Set the maximum size of the decompressed request bodies, see compression.DefaultMaxSize.
+// The larger requests are rejected with 413 Request Entity Too Large.
+func WithMaxDecompressedSize(size int64) ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.maxDecompressedSize = size
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
 )
 
 // Option is the function signature required to be considered an http.Option.
@@ -116,6 +118,41 @@ func WithStructuredEncoding() Option {
 	}
 }
 
+// WithRetries sets the retry params used by the http sender when a request fails
+// with a network error or with one of the retryable status codes.
+// If no retryable status codes are provided, DefaultRetryableStatusCodes are used.
+// The retry params in the context, set with context.WithRetryParams, take precedence.
+func WithRetries(params cecontext.RetryParams, retryableStatusCodes ...int) Option {
+	return func(t *Transport) error {
+		if t == nil {
+			return fmt.Errorf("http retries option can not set nil transport")
+		}
+		if params.Strategy != cecontext.BackoffStrategyNone && params.MaxTries < 1 {
+			return fmt.Errorf("http retries option was given an invalid max tries: %d", params.MaxTries)
+		}
+		t.senderOptions = append(t.senderOptions, WithRetryParams(params))
+		if len(retryableStatusCodes) > 0 {
+			t.senderOptions = append(t.senderOptions, WithRetryableStatusCodes(retryableStatusCodes...))
+		}
+		return nil
+	}
+}
+
+// WithConcurrency sets the maximum number of incoming requests delivered in parallel by StartReceiver.
+// By default, the requests are delivered one at a time.
+func WithConcurrency(concurrency int) Option {
+	return func(t *Transport) error {
+		if t == nil {
+			return fmt.Errorf("http concurrency option can not set nil transport")
+		}
+		if concurrency < 1 {
+			return fmt.Errorf("http concurrency option was given an invalid concurrency: %d", concurrency)
+		}
+		t.Concurrency = concurrency
+		return nil
+	}
+}
+
 func checkListen(t *Transport, prefix string) error {
 	switch {
 	case t.Port != nil:
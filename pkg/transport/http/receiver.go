@@ -2,12 +2,16 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	nethttp "net/http"
+	"strconv"
+	"strings"
 
 	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/compression"
 )
 
 type msgErr struct {
@@ -21,13 +25,23 @@ type Receiver struct {
 	incoming chan msgErr
 
 	transformers binding.TransformerFactories
+
+	// compressResponses enables the compression of the responses, negotiated through the Accept-Encoding header
+	compressResponses bool
+	// maxDecompressedSize is the maximum size of the decompressed request bodies
+	maxDecompressedSize int64
 }
 
 // ServeHTTP implements http.Handler.
 // Blocks until Message.Finish is called.
 func (r *Receiver) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	var err error
-	m := NewMessageFromHttpRequest(req)
+	if contentEncoding := req.Header.Get(ContentEncoding); contentEncoding != "" && contentEncoding != "identity" && compression.Lookup(contentEncoding) == nil {
+		rw.Header().Set(AcceptEncoding, strings.Join(compression.Names(), ", "))
+		nethttp.Error(rw, fmt.Sprintf("unsupported content encoding %q", contentEncoding), http.StatusUnsupportedMediaType)
+		return
+	}
+	m := newMessage(req.Header, req.Body, r.maxDecompressedSize)
 	if m.ReadEncoding() == binding.EncodingUnknown {
 		r.incoming <- msgErr{nil, binding.ErrUnknownEncoding}
 	}
@@ -35,7 +49,21 @@ func (r *Receiver) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	m.OnFinish = func(err error) error {
 		//status := http.StatusNoContent
 		if m.resp != nil {
-			err := EncodeHttpResponseWriter(context.Background(), m.resp, rw, r.transformers)
+			var crw *compressingResponseWriter
+			if r.compressResponses {
+				if codec := negotiateEncoding(req.Header.Get(AcceptEncoding)); codec != nil {
+					crw = &compressingResponseWriter{ResponseWriter: rw, codec: codec}
+				}
+			}
+			var err error
+			if crw != nil {
+				err = EncodeHttpResponseWriter(context.Background(), m.resp, crw, r.transformers)
+				if closeErr := crw.Close(); err == nil {
+					err = closeErr
+				}
+			} else {
+				err = EncodeHttpResponseWriter(context.Background(), m.resp, rw, r.transformers)
+			}
 			_ = m.resp.Finish(err)
 		}
 		m.resp = nil
@@ -44,14 +72,26 @@ func (r *Receiver) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	}
 	r.incoming <- msgErr{m, err} // Send to Receive()
 	if err = <-done; err != nil {
-		nethttp.Error(rw, fmt.Sprintf("cannot forward CloudEvent: %v", err), http.StatusInternalServerError)
+		status := http.StatusInternalServerError
+		if errors.Is(err, compression.ErrTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		nethttp.Error(rw, fmt.Sprintf("cannot forward CloudEvent: %v", err), status)
 	}
 }
 
 // NewReceiver creates a Receiver which implements http.Handler.
 // To receive messages, associate it with a http.Server.
-func NewReceiver() *Receiver {
-	return &Receiver{incoming: make(chan msgErr)}
+// The requests compressed with the codecs registered in the compression package are decompressed,
+// the requests compressed with other codecs are rejected with 415 Unsupported Media Type.
+// The requests decompressed to more than compression.DefaultMaxSize bytes, or the size set with
+// WithMaxDecompressedSize, are rejected with 413 Request Entity Too Large.
+func NewReceiver(options ...ReceiverOptionFunc) *Receiver {
+	r := &Receiver{incoming: make(chan msgErr), maxDecompressedSize: compression.DefaultMaxSize}
+	for _, o := range options {
+		o(r)
+	}
+	return r
 }
 
 // Receive the next incoming HTTP request as a CloudEvent.
@@ -64,3 +104,57 @@ func (r *Receiver) Receive(ctx context.Context) (binding.Message, error) {
 	}
 	return msgErr.msg, msgErr.err
 }
+
+// negotiateEncoding returns the first registered codec listed in the Accept-Encoding header acceptEncoding,
+// skipping the codecs with quality value 0, or nil.
+func negotiateEncoding(acceptEncoding string) compression.Codec {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(part, ";")
+		rejected := false
+		for _, param := range params[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) == 2 && kv[0] == "q" {
+				q, err := strconv.ParseFloat(kv[1], 64)
+				rejected = err != nil || q == 0
+			}
+		}
+		if rejected {
+			continue
+		}
+		if codec := compression.Lookup(strings.TrimSpace(params[0])); codec != nil {
+			return codec
+		}
+	}
+	return nil
+}
+
+// compressingResponseWriter compresses the body with codec, setting the Content-Encoding header.
+type compressingResponseWriter struct {
+	http.ResponseWriter
+	codec  compression.Codec
+	writer io.WriteCloser
+}
+
+func (w *compressingResponseWriter) Write(p []byte) (int, error) {
+	if w.writer == nil {
+		w.Header().Set(ContentEncoding, w.codec.Name())
+		w.Header().Add("Vary", AcceptEncoding)
+		w.Header().Del(ContentLength)
+		writer, err := w.codec.NewWriter(w.ResponseWriter)
+		if err != nil {
+			return 0, err
+		}
+		w.writer = writer
+	}
+	return w.writer.Write(p)
+}
+
+// Close flushes the compressed body.
+func (w *compressingResponseWriter) Close() error {
+	if w.writer == nil {
+		return nil
+	}
+	// The length of the uncompressed body could have been set after writing it
+	w.Header().Del(ContentLength)
+	return w.writer.Close()
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/compression"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+func TestReceiverUnsupportedEncoding(t *testing.T) {
+	r := NewReceiver()
+	req := httptest.NewRequest("POST", "http://localhost", bytes.NewReader([]byte("data")))
+	req.Header.Set(ContentEncoding, "unknown")
+	rw := httptest.NewRecorder()
+	r.ServeHTTP(rw, req)
+	require.Equal(t, nethttp.StatusUnsupportedMediaType, rw.Code)
+	require.Equal(t, "gzip, snappy, zstd", rw.Header().Get(AcceptEncoding))
+}
+
+func TestReceiverMaxDecompressedSize(t *testing.T) {
+	e := test.FullEvent()
+	require.NoError(t, e.SetData(bytes.Repeat([]byte("compressible "), 100)))
+	body, err := e.MarshalJSON()
+	require.NoError(t, err)
+	compressed, err := compression.Compress(compression.Gzip, body)
+	require.NoError(t, err)
+
+	for size, want := range map[int64]int{
+		int64(len(body)):     nethttp.StatusOK,
+		int64(len(body)) - 1: nethttp.StatusRequestEntityTooLarge,
+	} {
+		r := NewReceiver(WithMaxDecompressedSize(size))
+		go func() {
+			m, err := r.Receive(context.Background())
+			if err != nil {
+				return
+			}
+			_, err = binding.ToEvent(context.Background(), m, nil)
+			_ = m.Finish(err)
+		}()
+		req := httptest.NewRequest("POST", "http://localhost", bytes.NewReader(compressed))
+		req.Header.Set(ContentType, event.ApplicationCloudEventsJSON)
+		req.Header.Set(ContentEncoding, compression.Gzip.Name())
+		rw := httptest.NewRecorder()
+		r.ServeHTTP(rw, req)
+		require.Equal(t, want, rw.Code, "max size %d", size)
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	testCases := map[string]string{
+		"":                       "",
+		"identity":               "",
+		"gzip":                   "gzip",
+		"br, zstd, gzip":         "zstd",
+		"zstd;q=0, GZIP;q=0.5":   "gzip",
+		"zstd;q=0.000, snappy":   "snappy",
+		"gzip;q=invalid, snappy": "snappy",
+		"*":                      "",
+	}
+	for acceptEncoding, want := range testCases {
+		t.Run(acceptEncoding, func(t *testing.T) {
+			codec := negotiateEncoding(acceptEncoding)
+			if want == "" {
+				require.Nil(t, codec)
+			} else {
+				require.NotNil(t, codec)
+				require.Equal(t, want, codec.Name())
+			}
+		})
+	}
+}
@@ -36,6 +36,12 @@ func (b *httpRequestWriter) SetStructuredEvent(ctx context.Context, format forma
 	return nil
 }
 
+func (b *httpRequestWriter) SetBatchEvents(ctx context.Context, format format.BatchFormat, events io.Reader) error {
+	b.Header.Set(ContentType, format.MediaType())
+	b.Body = ioutil.NopCloser(events)
+	return nil
+}
+
 func (b *httpRequestWriter) Start(ctx context.Context) error {
 	return nil
 }
@@ -76,3 +82,4 @@ func (b *httpRequestWriter) SetExtension(name string, value interface{}) error {
 
 var _ binding.StructuredWriter = (*httpRequestWriter)(nil) // Test it conforms to the interface
 var _ binding.BinaryWriter = (*httpRequestWriter)(nil)     // Test it conforms to the interface
+var _ binding.BatchWriter = (*httpRequestWriter)(nil)      // Test it conforms to the interface
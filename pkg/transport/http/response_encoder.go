@@ -36,6 +36,12 @@ func (b *httpResponseEncoder) SetStructuredEvent(ctx context.Context, format for
 	return nil
 }
 
+func (b *httpResponseEncoder) SetBatchEvents(ctx context.Context, format format.BatchFormat, events io.Reader) error {
+	b.Header.Set(ContentType, format.MediaType())
+	b.Body = ioutil.NopCloser(events)
+	return nil
+}
+
 func (b *httpResponseEncoder) Start(ctx context.Context) error {
 	return nil
 }
@@ -76,3 +82,4 @@ func (b *httpResponseEncoder) SetExtension(name string, value interface{}) error
 
 var _ binding.StructuredWriter = (*httpResponseEncoder)(nil) // Test it conforms to the interface
 var _ binding.BinaryWriter = (*httpResponseEncoder)(nil)     // Test it conforms to the interface
+var _ binding.BatchWriter = (*httpResponseEncoder)(nil)      // Test it conforms to the interface
@@ -39,6 +39,11 @@ func (b *httpResponseWriterEncoder) SetStructuredEvent(ctx context.Context, form
 	return b.SetData(event)
 }
 
+func (b *httpResponseWriterEncoder) SetBatchEvents(ctx context.Context, format format.BatchFormat, events io.Reader) error {
+	b.rw.Header().Set(ContentType, format.MediaType())
+	return b.SetData(events)
+}
+
 func (b *httpResponseWriterEncoder) Start(ctx context.Context) error {
 	return nil
 }
@@ -82,5 +87,6 @@ func (b *httpResponseWriterEncoder) SetExtension(name string, value interface{})
 	return nil
 }
 
-var _ binding.StructuredWriter = (*httpResponseEncoder)(nil) // Test it conforms to the interface
-var _ binding.BinaryWriter = (*httpResponseEncoder)(nil)     // Test it conforms to the interface
+var _ binding.StructuredWriter = (*httpResponseEncoder)(nil)  // Test it conforms to the interface
+var _ binding.BinaryWriter = (*httpResponseEncoder)(nil)      // Test it conforms to the interface
+var _ binding.BatchWriter = (*httpResponseWriterEncoder)(nil) // Test it conforms to the interface
@@ -1,18 +1,37 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	nethttp "net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/cloudevents/sdk-go/pkg/binding/compression"
 	cecontext "github.com/cloudevents/sdk-go/pkg/context"
+	"github.com/cloudevents/sdk-go/pkg/observability"
 	bindings "github.com/cloudevents/sdk-go/pkg/transport"
 
 	"github.com/cloudevents/sdk-go/pkg/binding"
 )
 
+// maxResultBodySize is the maximum size of the response body attached to the transport.Result of failed requests
+const maxResultBodySize = 64 * 1024
+
+// DefaultRetryableStatusCodes is the default set of response status codes which make the Sender retry the request
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
 // Sender implements binding.Sender wrapping a nethttp.Client and a target URL
 type Sender struct {
 	// Client is the HTTP client used to send events as HTTP requests
@@ -25,6 +44,13 @@ type Sender struct {
 	RequestTemplate *http.Request
 
 	transformers binding.TransformerFactories
+
+	// compression is the codec compressing the request bodies, nil to not compress them
+	compression compression.Codec
+
+	// retryParams are the default retry params, overridden by the ones in the context passed to Send or Request
+	retryParams          cecontext.RetryParams
+	retryableStatusCodes map[int]bool
 }
 
 func NewRequester(client *http.Client, target *url.URL, options ...SenderOptionFunc) bindings.Requester {
@@ -32,7 +58,9 @@ func NewRequester(client *http.Client, target *url.URL, options ...SenderOptionF
 		Client:          client,
 		RequestTemplate: &http.Request{Method: http.MethodPost, URL: target},
 		transformers:    make(binding.TransformerFactories, 0),
+		retryParams:     cecontext.DefaultRetryParams,
 	}
+	WithRetryableStatusCodes(DefaultRetryableStatusCodes...)(s)
 	for _, o := range options {
 		o(s)
 	}
@@ -66,17 +94,133 @@ func (s *Sender) Request(ctx context.Context, m binding.Message) (binding.Messag
 	if err = WriteHttpRequest(ctx, m, req, s.transformers); err != nil {
 		return nil, err
 	}
-	resp, err := s.Client.Do(req)
+	if s.compression != nil {
+		if err = compressRequest(req, s.compression); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := s.do(ctx, req)
 	if err != nil {
+		err = bindings.NewUndelivered(TransportName, err)
 		return nil, err
 	}
 	if resp.StatusCode/100 != 2 {
-		return nil, fmt.Errorf("%d %s", resp.StatusCode, nethttp.StatusText(resp.StatusCode))
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxResultBodySize))
+		_ = resp.Body.Close()
+		err = bindings.NewNACK(TransportName, resp.StatusCode, body, fmt.Errorf("%d %s", resp.StatusCode, nethttp.StatusText(resp.StatusCode)))
+		return nil, err
 	}
 
 	return NewMessage(resp.Header, resp.Body), nil
 }
 
+// do performs the request, retrying it when the retry params allow it and the
+// request fails with a network error or a retryable status code.
+func (s *Sender) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	params := s.retryParams
+	if p := cecontext.RetryParamsFrom(ctx); p != nil {
+		params = *p
+	}
+
+	attempts := params.Attempts()
+	if attempts == 1 {
+		observability.ReportAttempt(ctx)
+		return s.Client.Do(req)
+	}
+
+	// Buffer the body, so it can be sent several times
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.ContentLength = int64(len(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	for tries := 1; ; tries++ {
+		if body != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		observability.ReportAttempt(ctx)
+		resp, err := s.Client.Do(req)
+		if tries >= attempts || ctx.Err() != nil || !s.isRetryable(resp, err) {
+			return resp, err
+		}
+
+		delay := params.BackoffFor(tries)
+		if resp != nil {
+			if retryAfter := retryAfterFrom(resp); retryAfter > delay {
+				delay = retryAfter
+				// The server can't delay the retry beyond the cap of the params
+				if params.MaxPeriod > 0 && delay > params.MaxPeriod {
+					delay = params.MaxPeriod
+				}
+			}
+			// Drain the body to reuse the connection
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		if err := cecontext.Sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// compressRequest compresses the body of req with codec, and sets the Accept-Encoding header
+// to accept the responses compressed with the registered codecs.
+func compressRequest(req *http.Request, codec compression.Codec) error {
+	req.Header.Set(AcceptEncoding, strings.Join(compression.Names(), ", "))
+	if req.Body == nil {
+		return nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		req.Body = nil
+		return nil
+	}
+	if body, err = compression.Compress(codec, body); err != nil {
+		return err
+	}
+	req.Header.Set(ContentEncoding, codec.Name())
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	return nil
+}
+
+func (s *Sender) isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return s.retryableStatusCodes[resp.StatusCode]
+}
+
+// retryAfterFrom parses the Retry-After header, as either delay seconds or http date.
+// Returns 0 if the header is missing or invalid.
+func retryAfterFrom(resp *http.Response) time.Duration {
+	h := resp.Header.Get("Retry-After")
+	if h == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(h); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(h); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
 func (s *Sender) makeRequest(ctx context.Context) *http.Request {
 	// TODO: support custom headers from context?
 	req := &http.Request{
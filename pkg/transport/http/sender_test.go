@@ -0,0 +1,257 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/compression"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/transport"
+)
+
+// statusServer replies with the provided status codes, in order, and then with 200
+type statusServer struct {
+	mu       sync.Mutex
+	statuses []int
+	headers  nethttp.Header
+	bodies   []string
+}
+
+func (s *statusServer) ServeHTTP(rw nethttp.ResponseWriter, req *nethttp.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, _ := ioutil.ReadAll(req.Body)
+	s.bodies = append(s.bodies, string(b))
+	status := nethttp.StatusOK
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	if status != nethttp.StatusOK {
+		for k, v := range s.headers {
+			rw.Header()[k] = v
+		}
+	}
+	rw.WriteHeader(status)
+}
+
+func testSender(t *testing.T, srv *statusServer, options ...SenderOptionFunc) (*Sender, func()) {
+	server := httptest.NewServer(srv)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return NewSender(server.Client(), u, options...).(*Sender), server.Close
+}
+
+func TestSenderRetries(t *testing.T) {
+	testCases := map[string]struct {
+		statuses     []int
+		options      []SenderOptionFunc
+		ctx          context.Context
+		wantAttempts int
+		wantErr      bool
+	}{
+		"no retry params": {
+			statuses:     []int{nethttp.StatusServiceUnavailable},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		"retry until success": {
+			statuses:     []int{nethttp.StatusServiceUnavailable, nethttp.StatusTooManyRequests},
+			options:      []SenderOptionFunc{WithRetryParams(cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Millisecond, MaxTries: 5})},
+			wantAttempts: 3,
+		},
+		"retry until max tries": {
+			statuses:     []int{nethttp.StatusServiceUnavailable, nethttp.StatusServiceUnavailable, nethttp.StatusServiceUnavailable},
+			options:      []SenderOptionFunc{WithRetryParams(cecontext.RetryParams{Strategy: cecontext.BackoffStrategyExponential, Period: time.Millisecond, MaxTries: 2})},
+			wantAttempts: 2,
+			wantErr:      true,
+		},
+		"non retryable status code": {
+			statuses:     []int{nethttp.StatusBadRequest},
+			options:      []SenderOptionFunc{WithRetryParams(cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Millisecond, MaxTries: 5})},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		"custom retryable status code": {
+			statuses: []int{nethttp.StatusBadRequest},
+			options: []SenderOptionFunc{
+				WithRetryParams(cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Millisecond, MaxTries: 5}),
+				WithRetryableStatusCodes(nethttp.StatusBadRequest),
+			},
+			wantAttempts: 2,
+		},
+		"retry params from context": {
+			statuses:     []int{nethttp.StatusServiceUnavailable},
+			ctx:          cecontext.WithRetriesLinearBackoff(context.Background(), time.Millisecond, 2),
+			wantAttempts: 2,
+		},
+		"context overrides sender retry params": {
+			statuses:     []int{nethttp.StatusServiceUnavailable},
+			options:      []SenderOptionFunc{WithRetryParams(cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Millisecond, MaxTries: 5})},
+			ctx:          cecontext.WithRetryParams(context.Background(), cecontext.DefaultRetryParams),
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			srv := &statusServer{statuses: tc.statuses}
+			s, closeFn := testSender(t, srv, tc.options...)
+			defer closeFn()
+
+			ctx := tc.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			err := s.Send(binding.WithForceStructured(ctx), binding.EventMessage(test.FullEvent()))
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Len(t, srv.bodies, tc.wantAttempts)
+			for _, b := range srv.bodies {
+				require.Equal(t, string(test.MustJSON(test.FullEvent())), b)
+			}
+		})
+	}
+}
+
+func TestSenderRetryAfter(t *testing.T) {
+	srv := &statusServer{
+		statuses: []int{nethttp.StatusServiceUnavailable},
+		headers:  nethttp.Header{"Retry-After": []string{"1"}},
+	}
+	s, closeFn := testSender(t, srv, WithRetryParams(cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Millisecond, MaxTries: 2}))
+	defer closeFn()
+
+	start := time.Now()
+	require.NoError(t, s.Send(context.Background(), binding.EventMessage(test.MinEvent())))
+	require.True(t, time.Since(start) >= time.Second, "Retry-After header not honored")
+	require.Len(t, srv.bodies, 2)
+}
+
+func TestSenderRetryAfterMaxPeriod(t *testing.T) {
+	srv := &statusServer{
+		statuses: []int{nethttp.StatusServiceUnavailable},
+		headers:  nethttp.Header{"Retry-After": []string{"3600"}},
+	}
+	s, closeFn := testSender(t, srv, WithRetryParams(cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Millisecond, MaxPeriod: 10 * time.Millisecond, MaxTries: 2}))
+	defer closeFn()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, s.Send(ctx, binding.EventMessage(test.MinEvent())))
+	require.Len(t, srv.bodies, 2)
+}
+
+func TestSenderRetryCanceled(t *testing.T) {
+	srv := &statusServer{statuses: []int{nethttp.StatusServiceUnavailable}}
+	s, closeFn := testSender(t, srv, WithRetryParams(cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Hour, MaxTries: 2}))
+	defer closeFn()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := s.Send(ctx, binding.EventMessage(test.MinEvent()))
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+	require.True(t, transport.IsUndelivered(err))
+	require.Len(t, srv.bodies, 1)
+}
+
+func TestSenderResult(t *testing.T) {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(rw nethttp.ResponseWriter, req *nethttp.Request) {
+		rw.WriteHeader(nethttp.StatusBadRequest)
+		_, _ = rw.Write([]byte("invalid event"))
+	}))
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	s := NewSender(server.Client(), u)
+
+	err = s.Send(context.Background(), binding.EventMessage(test.MinEvent()))
+	require.EqualError(t, err, "400 Bad Request")
+	var result *transport.Result
+	require.True(t, errors.As(err, &result))
+	require.Equal(t, transport.ResultNACK, result.Kind)
+	require.Equal(t, TransportName, result.Protocol)
+	require.Equal(t, nethttp.StatusBadRequest, result.StatusCode)
+	require.Equal(t, []byte("invalid event"), result.Body)
+
+	// Closed server, message cannot be delivered
+	server.Close()
+	err = s.Send(context.Background(), binding.EventMessage(test.MinEvent()))
+	require.True(t, transport.IsUndelivered(err))
+	require.True(t, errors.As(err, &result))
+	require.Equal(t, transport.ResultUndelivered, result.Kind)
+}
+
+func TestSenderResultFinish(t *testing.T) {
+	srv := &statusServer{statuses: []int{nethttp.StatusNotFound}}
+	s, closeFn := testSender(t, srv)
+	defer closeFn()
+
+	var finishErr error
+	m := binding.WithFinish(binding.EventMessage(test.MinEvent()), func(err error) { finishErr = err })
+	err := s.Send(context.Background(), m)
+	require.True(t, transport.IsNACK(err))
+	require.Equal(t, err, finishErr)
+}
+
+func TestSenderCompression(t *testing.T) {
+	for _, codec := range []compression.Codec{compression.Gzip, compression.Zstd, compression.Snappy} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			var requestHeader, responseHeader nethttp.Header
+			r := NewReceiver(WithResponseCompression())
+			server := httptest.NewServer(nethttp.HandlerFunc(func(rw nethttp.ResponseWriter, req *nethttp.Request) {
+				requestHeader = req.Header.Clone()
+				r.ServeHTTP(rw, req)
+				responseHeader = rw.Header().Clone()
+			}))
+			defer server.Close()
+			u, err := url.Parse(server.URL)
+			require.NoError(t, err)
+			s := NewRequester(server.Client(), u, WithCompression(codec))
+
+			eventIn := test.FullEvent()
+			eventOut := test.FullEvent()
+			eventOut.SetID("response")
+			received := make(chan *event.Event, 1)
+			go func() {
+				m, err := r.Receive(context.Background())
+				if err != nil {
+					close(received)
+					return
+				}
+				e, err := binding.ToEvent(context.Background(), m, nil)
+				received <- e
+				m.(*Message).Response(context.Background(), binding.EventMessage(eventOut))
+				_ = m.Finish(err)
+			}()
+
+			resp, err := s.Request(context.Background(), binding.EventMessage(eventIn))
+			require.NoError(t, err)
+			require.Equal(t, codec.Name(), requestHeader.Get(ContentEncoding))
+			require.Equal(t, "gzip, snappy, zstd", requestHeader.Get(AcceptEncoding))
+			got := <-received
+			require.NotNil(t, got)
+			require.Equal(t, eventIn.Data, got.Data)
+
+			got, err = binding.ToEvent(context.Background(), resp, nil)
+			require.NoError(t, err)
+			require.Equal(t, "gzip", responseHeader.Get(ContentEncoding))
+			require.Equal(t, eventOut.ID(), got.ID())
+			require.Equal(t, eventOut.Data, got.Data)
+		})
+	}
+}
@@ -23,6 +23,9 @@ import (
 var _ transport.Transport = (*Transport)(nil)
 
 const (
+	// TransportName is the name of this transport.
+	TransportName = "HTTP"
+
 	// DefaultShutdownTimeout defines the default timeout given to the http.Server when calling Shutdown.
 	DefaultShutdownTimeout = time.Minute * 1
 )
@@ -55,6 +58,7 @@ type Transport struct {
 	middleware        []Middleware
 	Target            *url.URL         // TODO: this is here just to allow the options to mutate it.
 	RequestTemplate   *nethttp.Request // TODO: this is here just to allow the options to mutate it.
+	senderOptions     []SenderOptionFunc
 }
 
 func New(opts ...Option) (*Transport, error) {
@@ -65,12 +69,12 @@ func New(opts ...Option) (*Transport, error) {
 
 	if t.Requester == nil {
 		client := nethttp.DefaultClient
-		t.Requester = NewRequester(client, t.Target)
+		t.Requester = NewRequester(client, t.Target, t.senderOptions...)
 	}
 
 	if t.Sender == nil {
 		client := nethttp.DefaultClient
-		t.Sender = NewSender(client, t.Target)
+		t.Sender = NewSender(client, t.Target, t.senderOptions...)
 	}
 
 	if t.Receiver == nil {
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"context"
+	nethttp "net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+type deliveryFunc func(context.Context, event.Event, *event.EventResponse) error
+
+func (f deliveryFunc) Delivery(ctx context.Context, e event.Event, er *event.EventResponse) error {
+	return f(ctx, e, er)
+}
+
+func TestTransportConcurrency(t *testing.T) {
+	_, err := New(WithConcurrency(0))
+	require.Error(t, err)
+
+	tr, err := New(WithConcurrency(2), WithPort(0))
+	require.NoError(t, err)
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	tr.SetDelivery(deliveryFunc(func(context.Context, event.Event, *event.EventResponse) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	port := tr.GetPort()
+	stopped := make(chan error)
+	go func() { stopped <- tr.StartReceiver(ctx) }()
+
+	responses := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		req, err := nethttp.NewRequest("POST", "http://localhost:"+strconv.Itoa(port), nil)
+		require.NoError(t, err)
+		req.Header.Set("ce-specversion", "1.0")
+		req.Header.Set("ce-id", strconv.Itoa(i))
+		req.Header.Set("ce-source", "/source")
+		req.Header.Set("ce-type", "type")
+		go func() {
+			resp, err := nethttp.DefaultClient.Do(req)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+			responses <- err
+		}()
+	}
+
+	// Both requests are delivered before any of them is handled
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the requests are not delivered concurrently")
+		}
+	}
+	close(release)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, <-responses)
+	}
+	cancel()
+	require.NoError(t, <-stopped)
+}
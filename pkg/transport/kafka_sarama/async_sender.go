@@ -0,0 +1,108 @@
+package kafka_sarama
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+)
+
+// AsyncSender implements binding.Sender that sends messages to a specific topic using sarama.AsyncProducer.
+//
+// Send returns as soon as the message is queued in the producer, the message is finished
+// when the broker acknowledges it, or with the error if the delivery failed.
+type AsyncSender struct {
+	topic         string
+	asyncProducer sarama.AsyncProducer
+
+	transformers binding.TransformerFactories
+	keyMapper    KeyMapper
+
+	done sync.WaitGroup
+}
+
+// Returns a binding.Sender that sends messages to a specific topic using sarama.AsyncProducer.
+// The client must be configured with Producer.Return.Successes and Producer.Return.Errors
+// enabled, in order to finish the messages with the delivery reports.
+func NewAsyncSender(client sarama.Client, topic string, options ...AsyncSenderOptionFunc) (*AsyncSender, error) {
+	if !client.Config().Producer.Return.Successes || !client.Config().Producer.Return.Errors {
+		return nil, errors.New("kafka_sarama.AsyncSender requires Producer.Return.Successes and Producer.Return.Errors")
+	}
+	producer, err := sarama.NewAsyncProducerFromClient(client)
+	if err != nil {
+		return nil, err
+	}
+	return newAsyncSender(producer, topic, options...), nil
+}
+
+func newAsyncSender(producer sarama.AsyncProducer, topic string, options ...AsyncSenderOptionFunc) *AsyncSender {
+	s := &AsyncSender{
+		topic:         topic,
+		asyncProducer: producer,
+		transformers:  make(binding.TransformerFactories, 0),
+	}
+	for _, o := range options {
+		o(s)
+	}
+
+	s.done.Add(2)
+	go func() {
+		defer s.done.Done()
+		for msg := range producer.Successes() {
+			finish(msg, nil)
+		}
+	}()
+	go func() {
+		defer s.done.Done()
+		for err := range producer.Errors() {
+			finish(err.Msg, toResult(err.Err))
+		}
+	}()
+	return s
+}
+
+// finish finishes the binding.Message carried in the metadata of msg.
+func finish(msg *sarama.ProducerMessage, err error) {
+	if m, ok := msg.Metadata.(binding.Message); ok {
+		_ = m.Finish(err)
+	}
+}
+
+// Send queues the message in the producer. m.Finish() is invoked with the delivery report.
+// If the message can't be queued, Send finishes m and returns the error.
+func (s *AsyncSender) Send(ctx context.Context, m binding.Message) (err error) {
+	kafkaMessage := sarama.ProducerMessage{Topic: s.topic, Metadata: m}
+
+	if err = writeProducerMessage(ctx, m, &kafkaMessage, s.transformers, s.keyMapper); err != nil {
+		_ = m.Finish(err)
+		return err
+	}
+
+	select {
+	case s.asyncProducer.Input() <- &kafkaMessage:
+		return nil
+	case <-ctx.Done():
+		_ = m.Finish(ctx.Err())
+		return ctx.Err()
+	}
+}
+
+// Close flushes the queued messages, waits for their delivery reports and shuts down the producer.
+// Send must not be invoked after Close.
+func (s *AsyncSender) Close(ctx context.Context) error {
+	s.asyncProducer.AsyncClose()
+	done := make(chan struct{})
+	go func() {
+		s.done.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,76 @@
+package kafka_sarama
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/transport"
+)
+
+func TestAsyncSender(t *testing.T) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	producer := mocks.NewAsyncProducer(t, config)
+	producer.ExpectInputAndSucceed()
+	producer.ExpectInputAndFail(sarama.ErrMessageSizeTooLarge)
+
+	s := newAsyncSender(producer, "aTopic")
+
+	finished := make(chan error, 2)
+	send := func() {
+		m := binding.WithFinish(binding.EventMessage(test.FullEvent()), func(err error) { finished <- err })
+		require.NoError(t, s.Send(context.Background(), m))
+	}
+
+	send()
+	require.NoError(t, <-finished)
+
+	send()
+	err := <-finished
+	var result *transport.Result
+	require.True(t, errors.As(err, &result))
+	require.Equal(t, transport.ResultNACK, result.Kind)
+	require.Equal(t, int(sarama.ErrMessageSizeTooLarge), result.StatusCode)
+
+	require.NoError(t, s.Close(context.Background()))
+}
+
+// blockedProducer never consumes its input.
+type blockedProducer struct {
+	sarama.AsyncProducer
+}
+
+func (blockedProducer) Input() chan<- *sarama.ProducerMessage {
+	return make(chan *sarama.ProducerMessage)
+}
+
+func (blockedProducer) Successes() <-chan *sarama.ProducerMessage {
+	c := make(chan *sarama.ProducerMessage)
+	close(c)
+	return c
+}
+
+func (blockedProducer) Errors() <-chan *sarama.ProducerError {
+	c := make(chan *sarama.ProducerError)
+	close(c)
+	return c
+}
+
+func TestAsyncSenderCanceled(t *testing.T) {
+	s := newAsyncSender(blockedProducer{}, "aTopic")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var finished error
+	m := binding.WithFinish(binding.EventMessage(test.FullEvent()), func(err error) { finished = err })
+	require.Equal(t, context.Canceled, s.Send(ctx, m))
+	require.Equal(t, context.Canceled, finished)
+}
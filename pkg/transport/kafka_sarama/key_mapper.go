@@ -0,0 +1,77 @@
+package kafka_sarama
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/spec"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/extensions"
+	"github.com/cloudevents/sdk-go/pkg/types"
+)
+
+// KeyMapper returns the key of the Kafka message for the event, or nil to send the message without key.
+// Use it with WithKeyMapper to choose the partition of the events, instead of using the key extension.
+type KeyMapper func(e event.Event) (sarama.Encoder, error)
+
+var (
+	// SubjectKeyMapper uses the subject as key
+	SubjectKeyMapper = AttributeKeyMapper("subject")
+	// SourceKeyMapper uses the source as key
+	SourceKeyMapper = AttributeKeyMapper("source")
+	// PartitionKeyMapper uses the partitionkey extension as key, as defined by the Partitioning extension:
+	// https://github.com/cloudevents/spec/blob/master/extensions/partitioning.md
+	PartitionKeyMapper = AttributeKeyMapper(extensions.PartitionKeyExtension)
+)
+
+// AttributeKeyMapper uses the value of the named attribute or extension as key.
+// Events without the attribute are sent without key.
+func AttributeKeyMapper(name string) KeyMapper {
+	return func(e event.Event) (sarama.Encoder, error) {
+		var value interface{}
+		if version := spec.VS.Version(e.SpecVersion()); version != nil && version.Attribute(name) != nil {
+			value = version.Attribute(name).Get(e.Context)
+		} else {
+			value = e.Extensions()[name]
+		}
+		if value == nil {
+			return nil, nil
+		}
+		if b, ok := value.([]byte); ok {
+			return sarama.ByteEncoder(b), nil
+		}
+		s, err := types.Format(value)
+		if err != nil {
+			return nil, err
+		}
+		if s == "" {
+			return nil, nil
+		}
+		return sarama.StringEncoder(s), nil
+	}
+}
+
+// writeProducerMessage fills the producerMessage like WriteKafkaProducerMessage.
+// If keyMapper is not nil, the message is converted to an event to compute the key,
+// and the key extension is not handled.
+func writeProducerMessage(ctx context.Context, m binding.Message, producerMessage *sarama.ProducerMessage, transformers binding.TransformerFactories, keyMapper KeyMapper) error {
+	if keyMapper == nil {
+		return WriteKafkaProducerMessage(ctx, m, producerMessage, transformers)
+	}
+
+	e, err := binding.ToEvent(ctx, m, transformers)
+	if err != nil {
+		return err
+	}
+	key, err := keyMapper(*e)
+	if err != nil {
+		return err
+	}
+	if err := WriteKafkaProducerMessage(WithSkipKeyExtension(ctx), binding.EventMessage(*e), producerMessage, nil); err != nil {
+		return err
+	}
+	producerMessage.Key = key
+	return nil
+}
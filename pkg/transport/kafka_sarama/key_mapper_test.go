@@ -0,0 +1,62 @@
+package kafka_sarama
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+func TestKeyMappers(t *testing.T) {
+	e := test.FullEvent()
+	e.SetExtension("partitionkey", "aggregate-1")
+
+	tests := []struct {
+		name      string
+		keyMapper KeyMapper
+		want      sarama.Encoder
+	}{
+		{"subject", SubjectKeyMapper, sarama.StringEncoder("topic")},
+		{"source", SourceKeyMapper, sarama.StringEncoder(test.Source.String())},
+		{"partitionkey", PartitionKeyMapper, sarama.StringEncoder("aggregate-1")},
+		{"extension", AttributeKeyMapper("exint"), sarama.StringEncoder("42")},
+		{"missing", AttributeKeyMapper("nosuch"), nil},
+		{"custom", func(e event.Event) (sarama.Encoder, error) { return sarama.StringEncoder(e.Type()), nil }, sarama.StringEncoder(e.Type())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := tt.keyMapper(e)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, key)
+		})
+	}
+
+	key, err := SubjectKeyMapper(test.MinEvent())
+	require.NoError(t, err)
+	require.Nil(t, key)
+}
+
+func TestWriteProducerMessageWithKeyMapper(t *testing.T) {
+	e := test.FullEvent()
+	e.SetExtension("key", "aKey")
+
+	for _, m := range []binding.Message{binding.EventMessage(e), test.MustCreateMockBinaryMessage(e), test.MustCreateMockStructuredMessage(e)} {
+		producerMessage := sarama.ProducerMessage{}
+		require.NoError(t, writeProducerMessage(context.TODO(), m, &producerMessage, nil, SubjectKeyMapper))
+		require.Equal(t, sarama.StringEncoder("topic"), producerMessage.Key)
+
+		// The key extension is sent as header
+		var key string
+		for _, h := range producerMessage.Headers {
+			if string(h.Key) == prefix+"key" {
+				key = string(h.Value)
+			}
+		}
+		require.Equal(t, "aKey", key)
+	}
+}
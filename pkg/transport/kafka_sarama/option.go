@@ -1,6 +1,12 @@
 package kafka_sarama
 
-import "github.com/cloudevents/sdk-go/pkg/binding"
+import (
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+)
 
 // kafka_sarama.Sender options
 type SenderOptionFunc func(sender *Sender)
@@ -11,3 +17,88 @@ func WithTransformer(transformer binding.TransformerFactory) SenderOptionFunc {
 		sender.transformers = append(sender.transformers, transformer)
 	}
 }
+
+// Use keyMapper to compute the key of the Kafka messages, instead of the key extension.
+// The messages are converted to events to compute the key.
+func WithKeyMapper(keyMapper KeyMapper) SenderOptionFunc {
+	return func(sender *Sender) {
+		sender.keyMapper = keyMapper
+	}
+}
+
+// kafka_sarama.AsyncSender options
+type AsyncSenderOptionFunc func(sender *AsyncSender)
+
+// Add a transformer, which AsyncSender uses while encoding a binding.Message to a sarama.ProducerMessage
+func WithAsyncTransformer(transformer binding.TransformerFactory) AsyncSenderOptionFunc {
+	return func(sender *AsyncSender) {
+		sender.transformers = append(sender.transformers, transformer)
+	}
+}
+
+// Use keyMapper to compute the key of the Kafka messages, instead of the key extension.
+// The messages are converted to events to compute the key.
+func WithAsyncKeyMapper(keyMapper KeyMapper) AsyncSenderOptionFunc {
+	return func(sender *AsyncSender) {
+		sender.keyMapper = keyMapper
+	}
+}
+
+// kafka_sarama.Receiver options
+type ReceiverOptionFunc func(receiver *Receiver)
+
+// Consume the messages of the provided topics too
+func WithTopics(topics ...string) ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.topics = append(receiver.topics, topics...)
+	}
+}
+
+// Mark a message as consumed only if it's finished without errors.
+// Kafka commits the offsets of a partition, so after a message of a partition fails,
+// no other message of the partition is marked in the consumer group session: the failed message
+// and the following ones are consumed again after the next rebalance or restart.
+func WithCommitOnSuccess() ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.commitOnSuccess = true
+	}
+}
+
+// Start consuming the partitions from the provided offset, instead of the committed offset.
+// offset can be sarama.OffsetOldest or sarama.OffsetNewest.
+// Each partition is reset once, when it's claimed for the first time by the Receiver.
+func WithInitialOffset(offset int64) ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.seek = func(topic string, partition int32) (int64, error) {
+			if offset < 0 {
+				return receiver.client.GetOffset(topic, partition, offset)
+			}
+			return offset, nil
+		}
+	}
+}
+
+// Start consuming the partitions from the first message with a timestamp equal or later than t,
+// instead of the committed offset.
+// Each partition is reset once, when it's claimed for the first time by the Receiver.
+func WithInitialTimestamp(t time.Time) ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.seek = func(topic string, partition int32) (int64, error) {
+			return receiver.client.GetOffset(topic, partition, t.UnixNano()/int64(time.Millisecond))
+		}
+	}
+}
+
+// Invoke hook in Receiver.Setup, at the beginning of a new consumer group session
+func WithSetupHook(hook func(sarama.ConsumerGroupSession) error) ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.setupHook = hook
+	}
+}
+
+// Invoke hook in Receiver.Cleanup, at the end of a consumer group session
+func WithCleanupHook(hook func(sarama.ConsumerGroupSession) error) ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.cleanupHook = hook
+	}
+}
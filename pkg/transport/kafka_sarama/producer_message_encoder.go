@@ -11,6 +11,7 @@ import (
 	"github.com/cloudevents/sdk-go/pkg/binding"
 	"github.com/cloudevents/sdk-go/pkg/binding/format"
 	"github.com/cloudevents/sdk-go/pkg/binding/spec"
+	"github.com/cloudevents/sdk-go/pkg/extensions"
 	"github.com/cloudevents/sdk-go/pkg/types"
 )
 
@@ -27,13 +28,15 @@ func WithSkipKeyExtension(ctx context.Context) context.Context {
 // Using context you can tweak the encoding processing (more details on binding.Write documentation).
 // You can skip the key extension handling decorating the context using WithSkipKeyExtension:
 // https://github.com/cloudevents/spec/blob/master/kafka-protocol-binding.md#31-key-attribute
+// If the key extension is missing, the partitionkey extension is used as key:
+// https://github.com/cloudevents/spec/blob/master/extensions/partitioning.md
 func WriteKafkaProducerMessage(ctx context.Context, m binding.Message, producerMessage *sarama.ProducerMessage, transformerFactories binding.TransformerFactories) error {
 	skipKey := binding.GetOrDefaultFromCtx(ctx, SKIP_KEY_EXTENSION, false).(bool)
 
 	if skipKey {
 		enc := &kafkaProducerMessageWriter{
-			producerMessage,
-			skipKey,
+			ProducerMessage: producerMessage,
+			skipKey:         skipKey,
 		}
 
 		_, err := binding.Write(
@@ -51,8 +54,8 @@ func WriteKafkaProducerMessage(ctx context.Context, m binding.Message, producerM
 	// Skip direct encoding if the event is an event message
 	if enc == binding.EncodingBinary {
 		encoder := &kafkaProducerMessageWriter{
-			producerMessage,
-			skipKey,
+			ProducerMessage: producerMessage,
+			skipKey:         skipKey,
 		}
 		enc, err = binding.DirectWrite(ctx, m, nil, encoder, transformerFactories)
 		if enc != binding.EncodingUnknown {
@@ -74,13 +77,15 @@ func WriteKafkaProducerMessage(ctx context.Context, m binding.Message, producerM
 		}
 
 		producerMessage.Key = sarama.StringEncoder(s)
+	} else if pk, ok := extensions.GetPartitionKey(*e); ok {
+		producerMessage.Key = sarama.StringEncoder(pk)
 	}
 
 	eventMessage := binding.EventMessage(*e)
 
 	encoder := &kafkaProducerMessageWriter{
-		producerMessage,
-		skipKey,
+		ProducerMessage: producerMessage,
+		skipKey:         skipKey,
 	}
 
 	if binding.GetOrDefaultFromCtx(ctx, binding.PREFERRED_EVENT_ENCODING, binding.EncodingBinary).(binding.Encoding) == binding.EncodingStructured {
@@ -93,6 +98,7 @@ func WriteKafkaProducerMessage(ctx context.Context, m binding.Message, producerM
 type kafkaProducerMessageWriter struct {
 	*sarama.ProducerMessage
 	skipKey bool
+	keySet  bool
 }
 
 func (b *kafkaProducerMessageWriter) SetStructuredEvent(ctx context.Context, format format.Format, event io.Reader) error {
@@ -157,6 +163,7 @@ func (b *kafkaProducerMessageWriter) SetExtension(name string, value interface{}
 			}
 			b.Key = sarama.ByteEncoder(s)
 		}
+		b.keySet = true
 		return nil
 	}
 
@@ -166,6 +173,11 @@ func (b *kafkaProducerMessageWriter) SetExtension(name string, value interface{}
 		return err
 	}
 	b.Headers = append(b.Headers, sarama.RecordHeader{Key: []byte(prefix + name), Value: []byte(s)})
+
+	// The partitionkey extension is the key if the key extension is missing
+	if !b.skipKey && !b.keySet && name == extensions.PartitionKeyExtension && s != "" {
+		b.Key = sarama.ByteEncoder(s)
+	}
 	return nil
 }
 
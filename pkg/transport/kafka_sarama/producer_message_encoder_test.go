@@ -9,8 +9,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/format/avro"
 	"github.com/cloudevents/sdk-go/pkg/binding/test"
 	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/extensions"
 )
 
 func TestEncodeKafkaProducerMessage(t *testing.T) {
@@ -49,6 +51,13 @@ func TestEncodeKafkaProducerMessage(t *testing.T) {
 			expectedEncoding: binding.EncodingBinary,
 			skipKey:          true,
 		},
+		{
+			name:             "Event to Avro Structured with Skip key",
+			context:          binding.UseFormatForEvent(binding.WithForceStructured(context.TODO()), avro.Avro),
+			messageFactory:   func(e event.Event) binding.Message { return binding.EventMessage(e) },
+			expectedEncoding: binding.EncodingStructured,
+			skipKey:          true,
+		},
 		{
 			name:             "Structured to Structured",
 			context:          binding.WithPreferredEventEncoding(context.TODO(), binding.EncodingStructured),
@@ -132,3 +141,38 @@ func TestEncodeKafkaProducerMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeKafkaProducerMessagePartitionKey(t *testing.T) {
+	messageFactories := map[string]func(e event.Event) binding.Message{
+		"Binary":     func(e event.Event) binding.Message { return test.MustCreateMockBinaryMessage(e) },
+		"Structured": func(e event.Event) binding.Message { return test.MustCreateMockStructuredMessage(e) },
+		"Event":      func(e event.Event) binding.Message { return binding.EventMessage(e) },
+	}
+	for name, messageFactory := range messageFactories {
+		t.Run(name, func(t *testing.T) {
+			e := test.FullEvent()
+			require.NoError(t, extensions.SetPartitionKey(&e, "aPartitionKey"))
+
+			kafkaMessage := &sarama.ProducerMessage{}
+			require.NoError(t, WriteKafkaProducerMessage(context.TODO(), messageFactory(e), kafkaMessage, nil))
+			require.Equal(t, sarama.ByteEncoder("aPartitionKey"), toByteEncoder(t, kafkaMessage.Key))
+
+			// The key extension has precedence
+			e.SetExtension("key", "aKey")
+			kafkaMessage = &sarama.ProducerMessage{}
+			require.NoError(t, WriteKafkaProducerMessage(context.TODO(), messageFactory(e), kafkaMessage, nil))
+			require.Equal(t, sarama.ByteEncoder("aKey"), toByteEncoder(t, kafkaMessage.Key))
+
+			kafkaMessage = &sarama.ProducerMessage{}
+			require.NoError(t, WriteKafkaProducerMessage(WithSkipKeyExtension(context.TODO()), messageFactory(e), kafkaMessage, nil))
+			require.Nil(t, kafkaMessage.Key)
+		})
+	}
+}
+
+func toByteEncoder(t *testing.T, e sarama.Encoder) sarama.ByteEncoder {
+	require.NotNil(t, e)
+	b, err := e.Encode()
+	require.NoError(t, err)
+	return b
+}
@@ -3,6 +3,9 @@ package kafka_sarama
 import (
 	"context"
 	"io"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 
@@ -20,39 +23,89 @@ type Receiver struct {
 	incoming chan msgErr
 
 	client              sarama.Client
-	topic               string
+	topics              []string
 	groupId             string
 	saramaConsumerGroup sarama.ConsumerGroup
+
+	commitOnSuccess bool
+	// seek returns the offset where to start consuming a partition, nil to start from the committed offset
+	seek   func(topic string, partition int32) (int64, error)
+	seeked map[string]bool
+
+	setupHook   func(sarama.ConsumerGroupSession) error
+	cleanupHook func(sarama.ConsumerGroupSession) error
 }
 
 // NewReceiver creates a Receiver which implements sarama.ConsumerGroupHandler
 // After the first invocation of Receiver.Receive(), the sarama.ConsumerGroup is created and started.
-func NewReceiver(client sarama.Client, groupId string, topic string) *Receiver {
-	return &Receiver{
+func NewReceiver(client sarama.Client, groupId string, topic string, options ...ReceiverOptionFunc) *Receiver {
+	r := &Receiver{
 		incoming: make(chan msgErr),
 		client:   client,
 		groupId:  groupId,
-		topic:    topic,
+		topics:   []string{topic},
+		seeked:   make(map[string]bool),
 	}
+	for _, o := range options {
+		o(r)
+	}
+	return r
 }
 
 func (r *Receiver) Setup(sess sarama.ConsumerGroupSession) error {
+	if r.seek != nil {
+		for topic, partitions := range sess.Claims() {
+			for _, partition := range partitions {
+				key := topic + "/" + strconv.Itoa(int(partition))
+				if r.seeked[key] {
+					continue
+				}
+				offset, err := r.seek(topic, partition)
+				if err != nil {
+					return err
+				}
+				// ResetOffset only moves the offset backward and MarkOffset only forward,
+				// so exactly one of them moves it to offset.
+				sess.ResetOffset(topic, partition, offset, "")
+				sess.MarkOffset(topic, partition, offset, "")
+				r.seeked[key] = true
+			}
+		}
+	}
+	if r.setupHook != nil {
+		return r.setupHook(sess)
+	}
 	return nil
 }
 
-func (r *Receiver) Cleanup(sarama.ConsumerGroupSession) error {
+func (r *Receiver) Cleanup(sess sarama.ConsumerGroupSession) error {
+	if r.cleanupHook != nil {
+		return r.cleanupHook(sess)
+	}
 	return nil
 }
 
 func (r *Receiver) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	marker := &offsetMarker{session: session, commitOnSuccess: r.commitOnSuccess}
 	for message := range claim.Messages() {
 		m, err := NewMessageFromConsumerMessage(message)
 
 		if err != nil {
 			r.incoming <- msgErr{err: err}
 		} else {
+			message := message
 			r.incoming <- msgErr{
-				msg: binding.WithFinish(m, func(err error) { session.MarkMessage(message, "") }),
+				msg: &partitionMessage{
+					Message: binding.WithFinish(m, func(err error) {
+						marker.finish(message, err)
+					}),
+					metadata: ConsumerMetadata{
+						Topic:     message.Topic,
+						Partition: message.Partition,
+						Offset:    message.Offset,
+						Timestamp: message.Timestamp,
+					},
+				},
 			}
 		}
 
@@ -60,6 +113,74 @@ func (r *Receiver) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 	return nil
 }
 
+// offsetMarker marks the finished messages of a claimed partition as consumed.
+// With commitOnSuccess, after a message fails the offset of the partition is reset to the failed message
+// and no other message is marked, so the failed message is consumed again after the next rebalance.
+type offsetMarker struct {
+	session         sarama.ConsumerGroupSession
+	commitOnSuccess bool
+
+	mu     sync.Mutex
+	failed bool
+}
+
+func (m *offsetMarker) finish(message *sarama.ConsumerMessage, err error) {
+	if !m.commitOnSuccess {
+		m.session.MarkMessage(message, "")
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil {
+		m.failed = true
+		// ResetOffset moves the offset backward only, to the first failed message
+		m.session.ResetOffset(message.Topic, message.Partition, message.Offset, "")
+	} else if !m.failed {
+		m.session.MarkMessage(message, "")
+	}
+}
+
+// ConsumerMetadata describes where a received message was read from.
+type ConsumerMetadata struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	// Timestamp of the message, zero if the broker doesn't support timestamps
+	Timestamp time.Time
+}
+
+// ConsumerMetadataOf returns the metadata of a message received by a Receiver,
+// walking through the MessageWrapper chain.
+// It returns false if m was not received by a Receiver.
+func ConsumerMetadataOf(m binding.Message) (ConsumerMetadata, bool) {
+	for m != nil {
+		if pm, ok := m.(*partitionMessage); ok {
+			return pm.metadata, true
+		}
+		if mw, ok := m.(binding.MessageWrapper); ok {
+			m = mw.GetWrappedMessage()
+		} else {
+			break
+		}
+	}
+	return ConsumerMetadata{}, false
+}
+
+// partitionMessage wraps a received message, exposing its topic and partition as ordering key.
+// Use it with bindings.BindingTransport.OrderedByKey to handle in order the messages of the same partition.
+type partitionMessage struct {
+	binding.Message
+	metadata ConsumerMetadata
+}
+
+func (m *partitionMessage) GetWrappedMessage() binding.Message {
+	return m.Message
+}
+
+func (m *partitionMessage) OrderingKey() string {
+	return m.metadata.Topic + "/" + strconv.Itoa(int(m.metadata.Partition))
+}
+
 func (r *Receiver) Receive(ctx context.Context) (binding.Message, error) {
 	// Consumer Group not started!
 	if r.saramaConsumerGroup == nil {
@@ -70,7 +191,7 @@ func (r *Receiver) Receive(ctx context.Context) (binding.Message, error) {
 		r.saramaConsumerGroup = cg
 
 		go func() {
-			if err := cg.Consume(ctx, []string{r.topic}, r); err != nil {
+			if err := cg.Consume(ctx, r.topics, r); err != nil {
 				r.incoming <- msgErr{err: err}
 			}
 		}()
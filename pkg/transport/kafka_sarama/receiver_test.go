@@ -0,0 +1,159 @@
+package kafka_sarama
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+)
+
+// fakeSession tracks the offsets like the sarama partition offset managers:
+// MarkOffset only moves an offset forward, ResetOffset only backward, and the offsets are -1 until committed.
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+	claims  map[string][]int32
+	mu      sync.Mutex
+	offsets map[string]int64
+}
+
+func newFakeSession(claims map[string][]int32) *fakeSession {
+	return &fakeSession{claims: claims, offsets: map[string]int64{}}
+}
+
+func (s *fakeSession) Claims() map[string][]int32 { return s.claims }
+
+func (s *fakeSession) offset(topic string, partition int32) int64 {
+	if o, ok := s.offsets[topic+"/"+strconv.Itoa(int(partition))]; ok {
+		return o
+	}
+	return -1
+}
+
+func (s *fakeSession) MarkOffset(topic string, partition int32, offset int64, metadata string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if offset > s.offset(topic, partition) {
+		s.offsets[topic+"/"+strconv.Itoa(int(partition))] = offset
+	}
+}
+
+func (s *fakeSession) ResetOffset(topic string, partition int32, offset int64, metadata string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if offset <= s.offset(topic, partition) {
+		s.offsets[topic+"/"+strconv.Itoa(int(partition))] = offset
+	}
+}
+
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.MarkOffset(msg.Topic, msg.Partition, msg.Offset+1, metadata)
+}
+
+type fakeClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+func consumeClaim(t *testing.T, r *Receiver, session *fakeSession, offsets ...int64) []binding.Message {
+	claim := fakeClaim{messages: make(chan *sarama.ConsumerMessage, len(offsets))}
+	for _, offset := range offsets {
+		claim.messages <- &sarama.ConsumerMessage{Topic: "aTopic", Partition: 3, Offset: offset, Value: []byte("hello")}
+	}
+	close(claim.messages)
+	go func() { _ = r.ConsumeClaim(session, claim) }()
+	var messages []binding.Message
+	for range offsets {
+		in := <-r.incoming
+		require.NoError(t, in.err)
+		messages = append(messages, in.msg)
+	}
+	return messages
+}
+
+func TestReceiverConsumeClaim(t *testing.T) {
+	timestamp := time.Date(2020, 3, 21, 12, 34, 56, 0, time.UTC)
+	r := NewReceiver(nil, "aGroup", "aTopic")
+	session := newFakeSession(nil)
+	claim := fakeClaim{messages: make(chan *sarama.ConsumerMessage, 2)}
+	for offset := int64(1); offset <= 2; offset++ {
+		claim.messages <- &sarama.ConsumerMessage{Topic: "aTopic", Partition: 3, Offset: offset, Timestamp: timestamp, Value: []byte("hello")}
+	}
+	close(claim.messages)
+	go func() { _ = r.ConsumeClaim(session, claim) }()
+
+	m1 := (<-r.incoming).msg
+	m2 := (<-r.incoming).msg
+	md, ok := ConsumerMetadataOf(binding.WithFinish(m2, nil))
+	require.True(t, ok)
+	require.Equal(t, ConsumerMetadata{Topic: "aTopic", Partition: 3, Offset: 2, Timestamp: timestamp}, md)
+	require.Equal(t, "aTopic/3", m2.(*partitionMessage).OrderingKey())
+
+	// Without WithCommitOnSuccess, the failed messages are consumed too
+	require.NoError(t, m1.Finish(errors.New("failed")))
+	require.Equal(t, int64(2), session.offset("aTopic", 3))
+	require.NoError(t, m2.Finish(nil))
+	require.Equal(t, int64(3), session.offset("aTopic", 3))
+
+	_, ok = ConsumerMetadataOf(binding.EventMessage{})
+	require.False(t, ok)
+}
+
+func TestReceiverCommitOnSuccess(t *testing.T) {
+	r := NewReceiver(nil, "aGroup", "aTopic", WithCommitOnSuccess())
+
+	// A success following a failure doesn't commit past the failed message
+	session := newFakeSession(nil)
+	messages := consumeClaim(t, r, session, 1, 2, 3)
+	require.NoError(t, messages[0].Finish(nil))
+	require.Equal(t, int64(2), session.offset("aTopic", 3))
+	require.NoError(t, messages[1].Finish(errors.New("failed")))
+	require.NoError(t, messages[2].Finish(nil))
+	require.Equal(t, int64(2), session.offset("aTopic", 3))
+
+	// A failure following a success finished out of order resets the offset to the failed message
+	session = newFakeSession(nil)
+	messages = consumeClaim(t, r, session, 1, 2, 3)
+	require.NoError(t, messages[2].Finish(nil))
+	require.Equal(t, int64(4), session.offset("aTopic", 3))
+	require.NoError(t, messages[0].Finish(errors.New("failed")))
+	require.NoError(t, messages[1].Finish(nil))
+	require.Equal(t, int64(1), session.offset("aTopic", 3))
+}
+
+func TestReceiverSetup(t *testing.T) {
+	var setup, cleanup int
+	r := NewReceiver(nil, "aGroup", "aTopic",
+		WithTopics("otherTopic"),
+		WithInitialOffset(5),
+		WithSetupHook(func(sarama.ConsumerGroupSession) error { setup++; return nil }),
+		WithCleanupHook(func(sarama.ConsumerGroupSession) error { cleanup++; return nil }),
+	)
+	require.Equal(t, []string{"aTopic", "otherTopic"}, r.topics)
+
+	// Seek forward on a fresh consumer group, and backward from a committed offset
+	session := newFakeSession(map[string][]int32{"aTopic": {0, 1}})
+	session.offsets["aTopic/1"] = 8
+	require.NoError(t, r.Setup(session))
+	require.NoError(t, r.Cleanup(session))
+	require.Equal(t, int64(5), session.offset("aTopic", 0))
+	require.Equal(t, int64(5), session.offset("aTopic", 1))
+
+	// After a rebalance only the new partitions are reset
+	session = newFakeSession(map[string][]int32{"aTopic": {1, 2}})
+	session.offsets["aTopic/1"] = 7
+	session.offsets["aTopic/2"] = 2
+	require.NoError(t, r.Setup(session))
+	require.Equal(t, int64(7), session.offset("aTopic", 1))
+	require.Equal(t, int64(5), session.offset("aTopic", 2))
+
+	require.Equal(t, 2, setup)
+	require.Equal(t, 1, cleanup)
+}
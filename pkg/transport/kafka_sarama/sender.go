@@ -2,10 +2,17 @@ package kafka_sarama
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Shopify/sarama"
 
 	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/transport"
+)
+
+const (
+	// TransportName is the name of this transport.
+	TransportName = "Kafka"
 )
 
 // Sender implements binding.Sender that sends messages to a specific topic using sarama.SyncProducer
@@ -14,6 +21,7 @@ type Sender struct {
 	syncProducer sarama.SyncProducer
 
 	transformers binding.TransformerFactories
+	keyMapper    KeyMapper
 }
 
 // Returns a binding.Sender that sends messages to a specific topic using sarama.SyncProducer
@@ -37,12 +45,43 @@ func NewSender(client sarama.Client, topic string, options ...SenderOptionFunc)
 func (s *Sender) Send(ctx context.Context, m binding.Message) error {
 	kafkaMessage := sarama.ProducerMessage{Topic: s.topic}
 
-	if err := WriteKafkaProducerMessage(ctx, m, &kafkaMessage, s.transformers); err != nil {
+	if err := writeProducerMessage(ctx, m, &kafkaMessage, s.transformers, s.keyMapper); err != nil {
 		return err
 	}
 
 	_, _, err := s.syncProducer.SendMessage(&kafkaMessage)
-	return err
+	return toResult(err)
+}
+
+// retriableErrors are the errors of the brokers which are transient, like a leader election:
+// the message can be delivered if it's sent again.
+var retriableErrors = map[sarama.KError]bool{
+	sarama.ErrInvalidMessage:               true, // Corrupt message, e.g. altered on the network
+	sarama.ErrUnknownTopicOrPartition:      true,
+	sarama.ErrLeaderNotAvailable:           true,
+	sarama.ErrNotLeaderForPartition:        true,
+	sarama.ErrRequestTimedOut:              true,
+	sarama.ErrBrokerNotAvailable:           true,
+	sarama.ErrReplicaNotAvailable:          true,
+	sarama.ErrNetworkException:             true,
+	sarama.ErrNotEnoughReplicas:            true,
+	sarama.ErrNotEnoughReplicasAfterAppend: true,
+	sarama.ErrNotController:                true,
+	sarama.ErrKafkaStorageError:            true,
+}
+
+// toResult converts the error returned by the producer to a transport.Result.
+// The retriable errors of the brokers, and the errors of the producer, are undelivered;
+// the other errors of the brokers are NACKs, with the error code as status code.
+func toResult(err error) error {
+	if err == nil {
+		return nil
+	}
+	var kErr sarama.KError
+	if errors.As(err, &kErr) && !retriableErrors[kErr] {
+		return transport.NewNACK(TransportName, int(kErr), nil, err)
+	}
+	return transport.NewUndelivered(TransportName, err)
 }
 
 func (s *Sender) Close(ctx context.Context) error {
@@ -0,0 +1,28 @@
+package kafka_sarama
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/transport"
+)
+
+func TestToResult(t *testing.T) {
+	require.NoError(t, toResult(nil))
+
+	err := toResult(sarama.ErrMessageSizeTooLarge)
+	var result *transport.Result
+	require.True(t, errors.As(err, &result))
+	require.Equal(t, transport.ResultNACK, result.Kind)
+	require.Equal(t, TransportName, result.Protocol)
+	require.Equal(t, int(sarama.ErrMessageSizeTooLarge), result.StatusCode)
+
+	for _, retriable := range []error{sarama.ErrOutOfBrokers, sarama.ErrNotLeaderForPartition, sarama.ErrRequestTimedOut, sarama.ErrNotEnoughReplicas} {
+		err = toResult(retriable)
+		require.True(t, transport.IsUndelivered(err), retriable.Error())
+		require.True(t, errors.Is(err, retriable))
+	}
+}
@@ -0,0 +1,10 @@
+/*
+Module mqtt implements an MQTT binding using github.com/eclipse/paho.golang module.
+
+The binding writes MQTT v5 PUBLISH packets: binary mode maps the event attributes to
+User Properties and datacontenttype to the Content Type property, while structured mode
+sets the Content Type property to the event format media type.
+MQTT v3.1.1 supports only structured mode, hence a PUBLISH without Content Type and
+User Properties is read as a structured message using the JSON format.
+*/
+package mqtt
@@ -0,0 +1,56 @@
+package mqtt
+
+// Encoding to use for mqtt transport.
+type Encoding int32
+
+const (
+	// Default allows mqtt transport implementation to pick.
+	Default Encoding = iota
+	// BinaryV03 is Binary CloudEvents spec v0.3.
+	BinaryV03
+	// StructuredV03 is Structured CloudEvents spec v0.3.
+	StructuredV03
+	// BinaryV1 is Binary CloudEvents spec v1.0.
+	BinaryV1
+	// StructuredV1 is Structured CloudEvents spec v1.0.
+	// Use a structured encoding when the messages are consumed by MQTT v3.1.1 clients.
+	StructuredV1
+	// Unknown is unknown.
+	Unknown
+)
+
+// String pretty-prints the encoding as a string.
+func (e Encoding) String() string {
+	switch e {
+	case Default:
+		return "Default Encoding " + e.Version()
+
+	// Binary
+	case BinaryV03, BinaryV1:
+		return "Binary Encoding " + e.Version()
+
+	// Structured
+	case StructuredV03, StructuredV1:
+		return "Structured Encoding " + e.Version()
+
+	default:
+		return "Unknown Encoding"
+	}
+}
+
+// Version pretty-prints the encoding version as a string.
+func (e Encoding) Version() string {
+	switch e {
+	// Version 0.3
+	case BinaryV03, StructuredV03:
+		return "v0.3"
+
+	// Version 1.0
+	case BinaryV1, StructuredV1, Default:
+		return "v1.0"
+
+	// Unknown
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,122 @@
+package mqtt
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/eclipse/paho.golang/paho"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+	"github.com/cloudevents/sdk-go/pkg/binding/spec"
+)
+
+// MQTT User Properties holding the CloudEvents attributes are not prefixed
+var specs = spec.WithPrefix("")
+
+// Message holds an MQTT PUBLISH packet.
+// This message *can* be read several times safely
+type Message struct {
+	Topic       string
+	Payload     []byte
+	ContentType string
+	Properties  map[string]string
+	format      format.Format
+	version     spec.Version
+}
+
+// Check if mqtt.Message implements binding.Message
+var _ binding.Message = (*Message)(nil)
+
+// Returns a binding.Message that holds the provided PUBLISH packet.
+// The returned binding.Message *can* be read several times safely
+func NewMessage(p *paho.Publish) *Message {
+	var contentType string
+	var properties map[string]string
+	if p.Properties != nil {
+		contentType = p.Properties.ContentType
+		properties = p.Properties.User
+	}
+	return NewMessageFromComponents(p.Topic, p.Payload, contentType, properties)
+}
+
+// Returns a binding.Message that holds the provided PUBLISH packet components.
+// The returned binding.Message *can* be read several times safely
+func NewMessageFromComponents(topic string, payload []byte, contentType string, properties map[string]string) *Message {
+	m := &Message{
+		Topic:       topic,
+		Payload:     payload,
+		ContentType: contentType,
+		Properties:  properties,
+	}
+	if ft := format.Lookup(contentType); ft != nil {
+		m.format = ft
+	} else if v := specs.Version(properties[specs.PrefixedSpecVersionName()]); v != nil {
+		m.version = v
+	} else if contentType == "" && len(properties) == 0 {
+		// MQTT v3.1.1 message, which can only be structured
+		m.format = format.JSON
+	}
+	return m
+}
+
+func (m *Message) ReadEncoding() binding.Encoding {
+	if m.version != nil {
+		return binding.EncodingBinary
+	}
+	if m.format != nil {
+		return binding.EncodingStructured
+	}
+	return binding.EncodingUnknown
+}
+
+func (m *Message) ReadStructured(ctx context.Context, encoder binding.StructuredWriter) error {
+	if m.format != nil {
+		return encoder.SetStructuredEvent(ctx, m.format, bytes.NewReader(m.Payload))
+	}
+	return binding.ErrNotStructured
+}
+
+func (m *Message) ReadBinary(ctx context.Context, encoder binding.BinaryWriter) error {
+	if m.version == nil {
+		return binding.ErrNotBinary
+	}
+
+	err := encoder.Start(ctx)
+	if err != nil {
+		return err
+	}
+
+	if m.ContentType != "" {
+		err = encoder.SetAttribute(m.version.AttributeFromKind(spec.DataContentType), m.ContentType)
+		if err != nil {
+			return err
+		}
+	}
+
+	for k, v := range m.Properties {
+		attr := m.version.Attribute(k)
+		if attr != nil {
+			err = encoder.SetAttribute(attr, v)
+		} else {
+			err = encoder.SetExtension(k, v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(m.Payload) != 0 {
+		err = encoder.SetData(bytes.NewReader(m.Payload))
+		if err != nil {
+			return err
+		}
+	}
+
+	return encoder.End()
+}
+
+// Finish is a no-op, the client acknowledges the PUBLISH packet as soon as it's received
+func (m *Message) Finish(error) error {
+	return nil
+}
@@ -0,0 +1,53 @@
+package mqtt
+
+import (
+	"testing"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+func TestNewMessage(t *testing.T) {
+	testCases := map[string]struct {
+		publish  *paho.Publish
+		encoding binding.Encoding
+	}{
+		"structured": {
+			publish: &paho.Publish{
+				Payload:    test.MustJSON(test.MinEvent()),
+				Properties: &paho.PublishProperties{ContentType: event.ApplicationCloudEventsJSON},
+			},
+			encoding: binding.EncodingStructured,
+		},
+		"binary": {
+			publish: &paho.Publish{
+				Payload: []byte("hello"),
+				Properties: &paho.PublishProperties{
+					ContentType: "text/plain",
+					User:        map[string]string{"specversion": "1.0", "id": "1", "type": "t", "source": "s"},
+				},
+			},
+			encoding: binding.EncodingBinary,
+		},
+		"v3.1.1 structured": {
+			publish:  &paho.Publish{Payload: test.MustJSON(test.MinEvent())},
+			encoding: binding.EncodingStructured,
+		},
+		"unknown": {
+			publish: &paho.Publish{
+				Payload:    []byte("hello"),
+				Properties: &paho.PublishProperties{ContentType: "text/plain"},
+			},
+			encoding: binding.EncodingUnknown,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			require.Equal(t, tc.encoding, NewMessage(tc.publish).ReadEncoding())
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package mqtt
+
+import (
+	"github.com/eclipse/paho.golang/paho"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+)
+
+// mqtt.Sender options
+type SenderOptionFunc func(sender *Sender)
+
+// Add a transformer, which Sender uses while encoding a binding.Message to a paho.Publish
+func WithTransformer(transformer binding.TransformerFactory) SenderOptionFunc {
+	return func(sender *Sender) {
+		sender.transformers = append(sender.transformers, transformer)
+	}
+}
+
+// WithQoS sets the QoS of the PUBLISH packets sent by the Sender. Default is 0
+func WithQoS(qos byte) SenderOptionFunc {
+	return func(sender *Sender) {
+		sender.qos = qos
+	}
+}
+
+// WithRetain sets the retain flag of the PUBLISH packets sent by the Sender
+func WithRetain(retain bool) SenderOptionFunc {
+	return func(sender *Sender) {
+		sender.retain = retain
+	}
+}
+
+// mqtt.Receiver options, applied to the SUBSCRIBE packet sent by NewReceiver
+type ReceiverOptionFunc func(subscribe *paho.Subscribe)
+
+// WithSubscribeOptions sets the subscription options, like the maximum QoS, of the Receiver topic filter
+func WithSubscribeOptions(options paho.SubscribeOptions) ReceiverOptionFunc {
+	return func(subscribe *paho.Subscribe) {
+		for topic := range subscribe.Subscriptions {
+			subscribe.Subscriptions[topic] = options
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package mqtt
+
+import "github.com/eclipse/paho.golang/paho"
+
+// Option is the function signature required to be considered an mqtt.Option.
+type Option func(*Transport) error
+
+// WithEncoding sets the encoding for mqtt transport.
+func WithEncoding(encoding Encoding) Option {
+	return func(t *Transport) error {
+		t.Encoding = encoding
+		return nil
+	}
+}
+
+// WithConnect sets the CONNECT packet used to connect the client to the server,
+// e.g. to provide the client id or the credentials.
+func WithConnect(connect *paho.Connect) Option {
+	return func(t *Transport) error {
+		t.connect = connect
+		return nil
+	}
+}
+
+// WithSenderOption sets an option of the mqtt.Sender, like WithQoS
+func WithSenderOption(opt SenderOptionFunc) Option {
+	return func(t *Transport) error {
+		t.senderOpts = append(t.senderOpts, opt)
+		return nil
+	}
+}
+
+// WithReceiverOption sets an option of the mqtt.Receiver, like WithSubscribeOptions
+func WithReceiverOption(opt ReceiverOptionFunc) Option {
+	return func(t *Transport) error {
+		t.receiverOpts = append(t.receiverOpts, opt)
+		return nil
+	}
+}
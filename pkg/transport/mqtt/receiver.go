@@ -0,0 +1,82 @@
+package mqtt
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/eclipse/paho.golang/paho"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+)
+
+// receiverBufferSize is the number of messages the Receiver holds until they are received
+const receiverBufferSize = 100
+
+// Receiver implements binding.Receiver that receives the messages published to a topic filter using paho.Client.
+//
+// paho.Client acks the PUBLISH packets before routing them, each one in its own goroutine, so there is no flow
+// control towards the server: the Receiver holds up to receiverBufferSize messages until they are received,
+// then the routing goroutines wait for Receive. While they wait they hold the lock of the paho.StandardRouter,
+// so registering or unregistering other handlers on the same client waits for the messages to be received.
+// The messages are routed concurrently, hence they can be received out of order.
+type Receiver struct {
+	client   *paho.Client
+	topic    string
+	incoming chan *paho.Publish
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// NewReceiver subscribes to the provided topic filter and returns a Receiver for the messages published to it.
+// The client must be already connected and it must use a paho.StandardRouter (the default router).
+func NewReceiver(ctx context.Context, client *paho.Client, topic string, options ...ReceiverOptionFunc) (*Receiver, error) {
+	r := &Receiver{
+		client:   client,
+		topic:    topic,
+		incoming: make(chan *paho.Publish, receiverBufferSize),
+		closed:   make(chan struct{}),
+	}
+	sub := &paho.Subscribe{Subscriptions: map[string]paho.SubscribeOptions{topic: {}}}
+	for _, o := range options {
+		o(sub)
+	}
+
+	client.Router.RegisterHandler(topic, r.handle)
+	if _, err := client.Subscribe(ctx, sub); err != nil {
+		client.Router.UnregisterHandler(topic)
+		return nil, err
+	}
+	return r, nil
+}
+
+// handle passes the message to Receive, blocking when the buffer is full until it's received or the receiver is closed.
+func (r *Receiver) handle(p *paho.Publish) {
+	select {
+	case r.incoming <- p:
+	case <-r.closed:
+	}
+}
+
+func (r *Receiver) Receive(ctx context.Context) (binding.Message, error) {
+	select {
+	case p := <-r.incoming:
+		return NewMessage(p), nil
+	case <-r.closed:
+		return nil, io.EOF
+	case <-ctx.Done():
+		return nil, io.EOF
+	}
+}
+
+// Close unsubscribes from the topic filter. The paho.Client is owned by the caller
+func (r *Receiver) Close(ctx context.Context) error {
+	var err error
+	r.closeOnce.Do(func() {
+		close(r.closed)
+		r.client.Router.UnregisterHandler(r.topic)
+		_, err = r.client.Unsubscribe(ctx, &paho.Unsubscribe{Topics: []string{r.topic}})
+	})
+	return err
+}
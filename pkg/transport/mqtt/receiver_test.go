@@ -0,0 +1,59 @@
+package mqtt
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/stretchr/testify/require"
+)
+
+// routeAsync routes p in a goroutine, like paho.Client, returning a channel closed when handle returns
+func routeAsync(r *Receiver, p *paho.Publish) chan struct{} {
+	handled := make(chan struct{})
+	go func() {
+		r.handle(p)
+		close(handled)
+	}()
+	return handled
+}
+
+func requireBlocked(t *testing.T, handled chan struct{}) {
+	select {
+	case <-handled:
+		t.Fatal("handle returned with a full buffer")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestReceiverBackpressure(t *testing.T) {
+	r := &Receiver{incoming: make(chan *paho.Publish, receiverBufferSize), closed: make(chan struct{})}
+
+	// The buffered messages don't block the router
+	for i := 0; i < receiverBufferSize; i++ {
+		r.handle(&paho.Publish{Topic: strconv.Itoa(i)})
+	}
+
+	// When the buffer is full, the router waits for Receive
+	handled := routeAsync(r, &paho.Publish{Topic: "last"})
+	requireBlocked(t, handled)
+	m, err := r.Receive(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "0", m.(*Message).Topic)
+	<-handled
+
+	// When the receiver is closed, the router doesn't wait anymore
+	handled = routeAsync(r, &paho.Publish{})
+	requireBlocked(t, handled)
+	close(r.closed)
+	<-handled
+	for {
+		if _, err = r.Receive(context.Background()); err != nil {
+			break
+		}
+	}
+	require.Equal(t, io.EOF, err)
+}
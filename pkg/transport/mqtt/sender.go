@@ -0,0 +1,64 @@
+package mqtt
+
+import (
+	"context"
+
+	"github.com/eclipse/paho.golang/paho"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/transport"
+)
+
+// Sender implements binding.Sender that publishes messages to a specific topic using paho.Client
+type Sender struct {
+	client *paho.Client
+	topic  string
+	qos    byte
+	retain bool
+
+	transformers binding.TransformerFactories
+}
+
+// Returns a binding.Sender that publishes messages to a specific topic using paho.Client.
+// The client must be already connected.
+func NewSender(client *paho.Client, topic string, options ...SenderOptionFunc) *Sender {
+	s := &Sender{
+		client:       client,
+		topic:        topic,
+		transformers: make(binding.TransformerFactories, 0),
+	}
+	for _, o := range options {
+		o(s)
+	}
+	return s
+}
+
+func (s *Sender) Send(ctx context.Context, m binding.Message) error {
+	var err error
+	defer func() { _ = m.Finish(err) }()
+
+	pub := paho.Publish{Topic: s.topic, QoS: s.qos, Retain: s.retain}
+	if err = WritePublishMessage(ctx, m, &pub, s.transformers); err != nil {
+		return err
+	}
+
+	err = toResult(s.client.Publish(ctx, &pub))
+	return err
+}
+
+// toResult converts the outcome of paho.Client.Publish to a transport.Result.
+// Failure reason codes returned by the server are NACKs.
+func toResult(resp *paho.PublishResponse, err error) error {
+	if resp != nil && resp.ReasonCode >= 0x80 {
+		return transport.NewNACK(TransportName, int(resp.ReasonCode), nil, err)
+	}
+	if err != nil {
+		return transport.NewUndelivered(TransportName, err)
+	}
+	return nil
+}
+
+// Close is a no-op, the paho.Client is owned by the caller
+func (s *Sender) Close(ctx context.Context) error {
+	return nil
+}
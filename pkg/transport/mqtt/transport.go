@@ -0,0 +1,130 @@
+package mqtt
+
+import (
+	"context"
+	"net"
+
+	"github.com/eclipse/paho.golang/paho"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/spec"
+	"github.com/cloudevents/sdk-go/pkg/binding/transformer"
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
+	"github.com/cloudevents/sdk-go/pkg/transport"
+	"github.com/cloudevents/sdk-go/pkg/transport/bindings"
+)
+
+// Transport adheres to transport.Transport.
+var _ transport.Transport = (*Transport)(nil)
+
+const (
+	// TransportName is the name of this transport.
+	TransportName = "MQTT"
+)
+
+type Transport struct {
+	bindings.BindingTransport
+	connect      *paho.Connect
+	senderOpts   []SenderOptionFunc
+	receiverOpts []ReceiverOptionFunc
+
+	// Encoding
+	Encoding Encoding
+
+	// MQTT
+	Client *paho.Client
+	Topic  string
+}
+
+const defaultKeepAlive = 30
+
+// New creates a new mqtt transport, connected to the server listening on the provided TCP address.
+// Events are published to the topic, and the receiver subscribes to it.
+func New(server, topic string, opts ...Option) (*Transport, error) {
+	t := &Transport{
+		Topic:   topic,
+		connect: &paho.Connect{KeepAlive: defaultKeepAlive, CleanStart: true},
+	}
+	if err := t.applyOptions(opts...); err != nil {
+		return nil, err
+	}
+	if t.connect.KeepAlive == 0 {
+		// paho.Client doesn't support disabling the keep alive
+		t.connect.KeepAlive = defaultKeepAlive
+	}
+
+	conn, err := net.Dial("tcp", server)
+	if err != nil {
+		return nil, err
+	}
+
+	client := paho.NewClient()
+	client.Conn = conn
+	if _, err := client.Connect(context.Background(), t.connect); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	t.Client = client
+
+	t.BindingTransport.Sender, t.BindingTransport.SenderContextDecorators = t.applyEncoding()
+	return t, nil
+}
+
+func (t *Transport) applyEncoding() (transport.Sender, []func(context.Context) context.Context) {
+	switch t.Encoding {
+	case BinaryV03:
+		return NewSender(
+			t.Client, t.Topic,
+			append(t.senderOpts, WithTransformer(transformer.Version(spec.V03)))...,
+		), []func(context.Context) context.Context{binding.WithForceBinary}
+	case BinaryV1:
+		return NewSender(
+			t.Client, t.Topic,
+			append(t.senderOpts, WithTransformer(transformer.Version(spec.V1)))...,
+		), []func(context.Context) context.Context{binding.WithForceBinary}
+	case StructuredV03:
+		return NewSender(
+			t.Client, t.Topic,
+			append(t.senderOpts, WithTransformer(transformer.Version(spec.V03)))...,
+		), []func(context.Context) context.Context{binding.WithForceStructured}
+	case StructuredV1:
+		return NewSender(
+			t.Client, t.Topic,
+			append(t.senderOpts, WithTransformer(transformer.Version(spec.V1)))...,
+		), []func(context.Context) context.Context{binding.WithForceStructured}
+	}
+	return NewSender(t.Client, t.Topic, t.senderOpts...), []func(context.Context) context.Context{}
+}
+
+func (t *Transport) applyOptions(opts ...Option) error {
+	for _, fn := range opts {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StartReceiver implements Transport.StartReceiver
+// NOTE: This is a blocking call.
+func (t *Transport) StartReceiver(ctx context.Context) error {
+	logger := cecontext.LoggerFrom(ctx)
+	logger.Info("StartReceiver on ", t.Topic)
+
+	receiver, err := NewReceiver(ctx, t.Client, t.Topic, t.receiverOpts...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = receiver.Close(context.Background()) }()
+	t.BindingTransport.Receiver = receiver
+	return t.BindingTransport.StartReceiver(ctx)
+}
+
+// HasTracePropagation implements Transport.HasTracePropagation
+func (t *Transport) HasTracePropagation() bool {
+	return false
+}
+
+func (t *Transport) Close() error {
+	return t.Client.Disconnect(&paho.Disconnect{})
+}
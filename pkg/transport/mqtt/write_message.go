@@ -0,0 +1,87 @@
+package mqtt
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/eclipse/paho.golang/paho"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+	"github.com/cloudevents/sdk-go/pkg/binding/spec"
+	"github.com/cloudevents/sdk-go/pkg/types"
+)
+
+// Fill the provided publishMessage with the message m.
+// Using context you can tweak the encoding processing (more details on binding.Write documentation).
+func WritePublishMessage(ctx context.Context, m binding.Message, publishMessage *paho.Publish, transformers binding.TransformerFactories) error {
+	structuredWriter := (*pubMessageWriter)(publishMessage)
+	binaryWriter := (*pubMessageWriter)(publishMessage)
+
+	_, err := binding.Write(
+		ctx,
+		m,
+		structuredWriter,
+		binaryWriter,
+		transformers,
+	)
+	return err
+}
+
+type pubMessageWriter paho.Publish
+
+func (b *pubMessageWriter) SetStructuredEvent(ctx context.Context, format format.Format, event io.Reader) error {
+	val, err := ioutil.ReadAll(event)
+	if err != nil {
+		return err
+	}
+	b.Payload = val
+	b.Properties = &paho.PublishProperties{ContentType: format.MediaType()}
+	return nil
+}
+
+func (b *pubMessageWriter) Start(ctx context.Context) error {
+	b.Properties = &paho.PublishProperties{User: make(map[string]string)}
+	return nil
+}
+
+func (b *pubMessageWriter) End() error {
+	return nil
+}
+
+func (b *pubMessageWriter) SetData(reader io.Reader) error {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	b.Payload = data
+	return nil
+}
+
+func (b *pubMessageWriter) SetAttribute(attribute spec.Attribute, value interface{}) error {
+	// MQTT User Properties, everything is a string!
+	s, err := types.Format(value)
+	if err != nil {
+		return err
+	}
+
+	if attribute.Kind() == spec.DataContentType {
+		b.Properties.ContentType = s
+	} else {
+		b.Properties.User[attribute.Name()] = s
+	}
+	return nil
+}
+
+func (b *pubMessageWriter) SetExtension(name string, value interface{}) error {
+	s, err := types.Format(value)
+	if err != nil {
+		return err
+	}
+	b.Properties.User[name] = s
+	return nil
+}
+
+var _ binding.BinaryWriter = (*pubMessageWriter)(nil)     // Test it conforms to the interface
+var _ binding.StructuredWriter = (*pubMessageWriter)(nil) // Test it conforms to the interface
@@ -9,18 +9,22 @@ import (
 
 	"github.com/cloudevents/sdk-go/pkg/binding"
 	"github.com/cloudevents/sdk-go/pkg/binding/format"
+	"github.com/cloudevents/sdk-go/pkg/binding/spec"
+	"github.com/cloudevents/sdk-go/pkg/types"
 )
 
-// Fill the provided amqpMessage with the message m.
+// Fill the provided natsMessage with the message m.
+// In binary encoding the attributes are written in the ce- prefixed headers, which require a NATS server supporting headers.
 // Using context you can tweak the encoding processing (more details on binding.Write documentation).
 func WriteNATSMessage(ctx context.Context, m binding.Message, natsMessage *nats.Msg, transformers binding.TransformerFactories) error {
 	structuredWriter := (*natsMessageWriter)(natsMessage)
+	binaryWriter := (*natsMessageWriter)(natsMessage)
 
 	_, err := binding.Write(
 		ctx,
 		m,
 		structuredWriter,
-		nil,
+		binaryWriter,
 		transformers,
 	)
 	return err
@@ -34,10 +38,21 @@ func (b *natsMessageWriter) SetStructuredEvent(ctx context.Context, f format.For
 		return err
 	}
 	b.Data = val
+	// JSON is the default structured format, the content-type header is omitted
+	// so JSON messages can be published to NATS servers not supporting headers.
+	if f != format.JSON {
+		if b.Header == nil {
+			b.Header = make(nats.Header)
+		}
+		b.Header.Set(contentTypeHeader, f.MediaType())
+	}
 	return nil
 }
 
 func (b *natsMessageWriter) Start(ctx context.Context) error {
+	if b.Header == nil {
+		b.Header = make(nats.Header)
+	}
 	return nil
 }
 
@@ -45,4 +60,39 @@ func (b *natsMessageWriter) End() error {
 	return nil
 }
 
+func (b *natsMessageWriter) SetData(reader io.Reader) error {
+	val, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	b.Data = val
+	return nil
+}
+
+func (b *natsMessageWriter) SetAttribute(attribute spec.Attribute, value interface{}) error {
+	// NATS headers, everything is a string!
+	s, err := types.Format(value)
+	if err != nil {
+		return err
+	}
+
+	if attribute.Kind() == spec.DataContentType {
+		b.Header.Set(contentTypeHeader, s)
+	} else {
+		b.Header.Set(prefix+attribute.Name(), s)
+	}
+	return nil
+}
+
+func (b *natsMessageWriter) SetExtension(name string, value interface{}) error {
+	// NATS headers, everything is a string!
+	s, err := types.Format(value)
+	if err != nil {
+		return err
+	}
+	b.Header.Set(prefix+name, s)
+	return nil
+}
+
 var _ binding.StructuredWriter = (*natsMessageWriter)(nil) // Test it conforms to the interface
+var _ binding.BinaryWriter = (*natsMessageWriter)(nil)     // Test it conforms to the interface
@@ -3,41 +3,146 @@ package nats
 import (
 	"bytes"
 	"context"
+	"strings"
 
 	"github.com/cloudevents/sdk-go/pkg/binding"
 	"github.com/cloudevents/sdk-go/pkg/binding/format"
+	"github.com/cloudevents/sdk-go/pkg/binding/spec"
 	"github.com/nats-io/nats.go"
 )
 
-const prefix = "cloudEvents:" // Name prefix for AMQP properties that hold CE attributes.
+const (
+	prefix            = "ce-" // Name prefix for NATS headers that hold CE attributes.
+	contentTypeHeader = "content-type"
+)
+
+var specs = spec.WithPrefix(prefix)
 
 // Message implements binding.Message by wrapping an *nats.Msg.
 // This message *can* be read several times safely
 type Message struct {
 	Msg      *nats.Msg
 	encoding binding.Encoding
+	format   format.Format
+	version  spec.Version
+
+	resp    binding.Message
+	respCtx context.Context
 }
 
 // Wrap an *nats.Msg in a binding.Message.
+// The message is in binary encoding if it has the ce-specversion header, otherwise it's in structured encoding,
+// with the format of the content-type header, or JSON if the header is missing.
 // The returned message *can* be read several times safely
 func NewMessage(msg *nats.Msg) *Message {
-	return &Message{Msg: msg, encoding: binding.EncodingStructured}
+	if v := specs.Version(headerValue(msg.Header, specs.PrefixedSpecVersionName())); v != nil {
+		return &Message{Msg: msg, encoding: binding.EncodingBinary, version: v}
+	}
+	if contentType := headerValue(msg.Header, contentTypeHeader); contentType != "" {
+		if f := format.Lookup(contentType); f != nil {
+			return &Message{Msg: msg, encoding: binding.EncodingStructured, format: f}
+		}
+		return &Message{Msg: msg, encoding: binding.EncodingUnknown}
+	}
+	return &Message{Msg: msg, encoding: binding.EncodingStructured, format: format.JSON}
+}
+
+// headerValue returns the first value of the header name, ignoring the case of the header names.
+func headerValue(header nats.Header, name string) string {
+	if v := header.Get(name); v != "" {
+		return v
+	}
+	for k, v := range header {
+		if strings.EqualFold(k, name) && len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
 }
 
 var _ binding.Message = (*Message)(nil)
 
+// Check if nats.Message implements binding.ResponseMessage
+var _ binding.ResponseMessage = (*Message)(nil)
+
 func (m *Message) ReadEncoding() binding.Encoding {
 	return m.encoding
 }
 
 func (m *Message) ReadStructured(ctx context.Context, encoder binding.StructuredWriter) error {
-	return encoder.SetStructuredEvent(ctx, format.JSON, bytes.NewReader(m.Msg.Data))
+	if m.format == nil {
+		return binding.ErrNotStructured
+	}
+	return encoder.SetStructuredEvent(ctx, m.format, bytes.NewReader(m.Msg.Data))
 }
 
 func (m *Message) ReadBinary(ctx context.Context, encoder binding.BinaryWriter) error {
-	return binding.ErrNotBinary
+	if m.version == nil {
+		return binding.ErrNotBinary
+	}
+
+	err := encoder.Start(ctx)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range m.Msg.Header {
+		if len(v) == 0 {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(k), prefix) {
+			attr := m.version.Attribute(k)
+			if attr != nil {
+				err = encoder.SetAttribute(attr, v[0])
+			} else {
+				err = encoder.SetExtension(strings.ToLower(k[len(prefix):]), v[0])
+			}
+		} else if strings.EqualFold(k, contentTypeHeader) {
+			err = encoder.SetAttribute(m.version.AttributeFromKind(spec.DataContentType), v[0])
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(m.Msg.Data) > 0 {
+		err = encoder.SetData(bytes.NewReader(m.Msg.Data))
+		if err != nil {
+			return err
+		}
+	}
+
+	return encoder.End()
+}
+
+// Response sets the response published to the reply subject of the request when the message is finished.
+// The response has the same encoding of the request.
+func (m *Message) Response(ctx context.Context, resp binding.Message) {
+	m.resp = resp
+	m.respCtx = ctx
 }
 
+// Finish publishes the response, if any, to the reply subject of the request.
 func (m *Message) Finish(err error) error {
-	return nil
+	if m.resp == nil {
+		return nil
+	}
+	resp := m.resp
+	m.resp = nil
+	if m.Msg.Reply == "" {
+		return resp.Finish(nil)
+	}
+	ctx := m.respCtx
+	if m.encoding == binding.EncodingBinary {
+		ctx = binding.WithForceBinary(ctx)
+	} else {
+		ctx = binding.WithForceStructured(ctx)
+	}
+	reply := &nats.Msg{}
+	respErr := WriteNATSMessage(ctx, resp, reply, nil)
+	if respErr == nil {
+		respErr = m.Msg.RespondMsg(reply)
+	}
+	_ = resp.Finish(respErr)
+	return respErr
 }
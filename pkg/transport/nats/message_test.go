@@ -0,0 +1,104 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/format/protobuf"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+func TestWriteAndNewMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding binding.Encoding
+	}{
+		{
+			name:     "Structured encoding",
+			encoding: binding.EncodingStructured,
+		},
+		{
+			name:     "Binary encoding",
+			encoding: binding.EncodingBinary,
+		},
+	}
+	for _, tt := range tests {
+		test.EachEvent(t, test.Events(), func(t *testing.T, eventIn event.Event) {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := context.TODO()
+				if tt.encoding == binding.EncodingStructured {
+					ctx = binding.WithForceStructured(ctx)
+				} else {
+					ctx = binding.WithForceBinary(ctx)
+				}
+
+				msg := &nats.Msg{}
+				require.NoError(t, WriteNATSMessage(ctx, binding.EventMessage(eventIn), msg, nil))
+				if tt.encoding == binding.EncodingStructured {
+					require.Nil(t, msg.Header)
+				} else {
+					require.Equal(t, eventIn.SpecVersion(), msg.Header.Get("ce-specversion"))
+				}
+
+				got := NewMessage(msg)
+				require.Equal(t, tt.encoding, got.ReadEncoding())
+				eventOut, err := binding.ToEvent(ctx, got, nil)
+				require.NoError(t, err)
+				test.AssertEventEquals(t, test.ExToStr(t, eventIn), test.ExToStr(t, *eventOut))
+			})
+		})
+	}
+}
+
+func TestWriteAndNewMessageStructuredFormat(t *testing.T) {
+	eventIn := test.FullEvent()
+	data, err := protobuf.Protobuf.Marshal(eventIn)
+	require.NoError(t, err)
+	in := &test.MockStructuredMessage{Format: protobuf.Protobuf, Bytes: data}
+
+	msg := &nats.Msg{}
+	require.NoError(t, WriteNATSMessage(context.TODO(), in, msg, nil))
+	require.Equal(t, protobuf.Protobuf.MediaType(), msg.Header.Get("content-type"))
+
+	got := NewMessage(msg)
+	require.Equal(t, binding.EncodingStructured, got.ReadEncoding())
+	eventOut, err := binding.ToEvent(context.TODO(), got, nil)
+	require.NoError(t, err)
+	test.AssertEventEquals(t, eventIn, *eventOut)
+}
+
+func TestNewMessageHeaders(t *testing.T) {
+	// Header names are case sensitive in NATS
+	msg := &nats.Msg{
+		Header: nats.Header{
+			"Ce-SpecVersion": {"1.0"},
+			"Ce-ID":          {"id"},
+			"Ce-Source":      {"source"},
+			"Ce-Type":        {"type"},
+			"Ce-ExString":    {"value"},
+			"Content-Type":   {"text/plain"},
+		},
+		Data: []byte("raw payload"),
+	}
+	got := NewMessage(msg)
+	require.Equal(t, binding.EncodingBinary, got.ReadEncoding())
+	e, err := binding.ToEvent(context.TODO(), got, nil)
+	require.NoError(t, err)
+	require.Equal(t, "id", e.ID())
+	require.Equal(t, "text/plain", e.DataContentType())
+	require.Equal(t, "value", e.Extensions()["exstring"])
+	require.Equal(t, []byte("raw payload"), e.Data)
+
+	// Without headers, the message is structured in JSON
+	require.Equal(t, binding.EncodingStructured, NewMessage(&nats.Msg{Data: []byte("{}")}).ReadEncoding())
+
+	unknown := NewMessage(&nats.Msg{Header: nats.Header{"content-type": {"text/plain"}}, Data: []byte("raw payload")})
+	require.Equal(t, binding.EncodingUnknown, unknown.ReadEncoding())
+	require.Equal(t, binding.ErrNotStructured, unknown.ReadStructured(context.TODO(), &test.MockStructuredMessage{}))
+	require.Equal(t, binding.ErrNotBinary, unknown.ReadBinary(context.TODO(), &test.MockBinaryMessage{}))
+}
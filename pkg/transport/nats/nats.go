@@ -12,6 +12,11 @@ const (
 	StructuredV1
 	// Unknown is unknown.
 	Unknown
+	// Binary is Binary CloudEvents, with the attributes in the message headers.
+	// It requires a NATS server supporting headers.
+	Binary
+	// Structured is Structured CloudEvents.
+	Structured
 )
 
 // String pretty-prints the encoding as a string.
@@ -20,8 +25,12 @@ func (e Encoding) String() string {
 	case Default:
 		return "Default Encoding " + e.Version()
 
+	// Binary
+	case Binary:
+		return "Binary Encoding " + e.Version()
+
 	// Structured
-	case StructuredV03, StructuredV1:
+	case StructuredV03, StructuredV1, Structured:
 		return "Structured Encoding " + e.Version()
 
 	default:
@@ -37,7 +46,7 @@ func (e Encoding) Version() string {
 		return "v0.3"
 
 	// Version 1.0
-	case StructuredV1, Default:
+	case StructuredV1, Binary, Structured, Default:
 		return "v1.0"
 
 	// Unknown
@@ -1,6 +1,8 @@
 package nats
 
 import (
+	"time"
+
 	"github.com/cloudevents/sdk-go/pkg/binding"
 	"github.com/nats-io/nats.go"
 )
@@ -9,6 +11,7 @@ import (
 type Option func(*Transport) error
 
 // WithEncoding sets the encoding for NATS transport.
+// Binary encoding requires a NATS server supporting headers, the other encodings are structured.
 func WithEncoding(encoding Encoding) Option {
 	return func(t *Transport) error {
 		t.Encoding = encoding
@@ -28,6 +31,22 @@ func WithConnOptions(opts ...nats.Option) Option {
 	}
 }
 
+// WithSenderOptions supplies the options of the Sender and Requester created by the transport
+func WithSenderOptions(opts ...SenderOptionFunc) Option {
+	return func(t *Transport) error {
+		t.SenderOptions = append(t.SenderOptions, opts...)
+		return nil
+	}
+}
+
+// WithReceiverOptions supplies the options of the Receiver created by the transport
+func WithReceiverOptions(opts ...ReceiverOptionFunc) Option {
+	return func(t *Transport) error {
+		t.ReceiverOptions = append(t.ReceiverOptions, opts...)
+		return nil
+	}
+}
+
 // nats.Sender options
 type SenderOptionFunc func(sender *sender)
 
@@ -37,3 +56,29 @@ func WithTransformer(transformer binding.TransformerFactory) SenderOptionFunc {
 		sender.transformers = append(sender.transformers, transformer)
 	}
 }
+
+// Set the time Requester waits for a response, when the context passed to Request has no deadline.
+// Defaults to DefaultRequestTimeout, 0 waits until the context is done.
+func WithRequestTimeout(timeout time.Duration) SenderOptionFunc {
+	return func(sender *sender) {
+		sender.requestTimeout = timeout
+	}
+}
+
+// Set the encoding of the messages sent by Sender and Requester.
+// Binary encoding requires a NATS server supporting headers, the other encodings are structured.
+func WithSenderEncoding(encoding Encoding) SenderOptionFunc {
+	return func(sender *sender) {
+		sender.encoding = encoding
+	}
+}
+
+// nats.Receiver options
+type ReceiverOptionFunc func(receiver *receiver)
+
+// Subscribe in the queue group, the messages are shared between the receivers of the same queue group
+func WithQueueGroup(queueGroup string) ReceiverOptionFunc {
+	return func(receiver *receiver) {
+		receiver.queueGroup = queueGroup
+	}
+}
@@ -26,6 +26,13 @@ func TestWithEncoding(t *testing.T) {
 				Encoding: StructuredV03,
 			},
 		},
+		"binary encoding": {
+			t:        &Transport{},
+			encoding: Binary,
+			want: &Transport{
+				Encoding: Binary,
+			},
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
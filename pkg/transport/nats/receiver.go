@@ -2,7 +2,7 @@ package nats
 
 import (
 	"context"
-	"time"
+	"io"
 
 	"github.com/cloudevents/sdk-go/pkg/binding"
 	"github.com/cloudevents/sdk-go/pkg/transport"
@@ -13,24 +13,23 @@ var _ transport.Receiver
 
 // receiver for nats, implements transport.Receiver
 type receiver struct {
-	conn    *nats.Conn
-	subject string
-	sub     *nats.Subscription
+	conn       *nats.Conn
+	subject    string
+	queueGroup string
+	sub        *nats.Subscription
+	err        error // subscription error
 }
 
+// Receive blocks until the next message is received, or ctx is done.
+// Returns io.EOF if ctx is done, or the receiver is closed.
 func (r *receiver) Receive(ctx context.Context) (binding.Message, error) {
-	var err error
-	if r.sub == nil {
-		r.sub, err = r.conn.SubscribeSync(r.subject)
-		if err != nil {
-			return nil, err
-		}
+	if r.err != nil {
+		return nil, r.err
+	}
+	msg, err := r.sub.NextMsgWithContext(ctx)
+	if ctx.Err() != nil || err == nats.ErrBadSubscription || err == nats.ErrConnectionClosed {
+		return nil, io.EOF
 	}
-
-	// TODO: allow to pass in a timeout
-	timeout := time.Second * 60
-
-	msg, err := r.sub.NextMsg(timeout)
 	if err != nil {
 		return nil, err
 	}
@@ -39,10 +38,25 @@ func (r *receiver) Receive(ctx context.Context) (binding.Message, error) {
 
 func (r *receiver) Close(ctx context.Context) error {
 	defer r.conn.Close()
+	if r.sub == nil {
+		return nil
+	}
 	return r.sub.Unsubscribe()
 }
 
-// Create a new Receiver which wraps an amqp.Receiver in a binding.Receiver
-func NewReceiver(conn *nats.Conn, subject string) transport.Receiver {
-	return &receiver{conn: conn, subject: subject}
+// Create a new Receiver subscribing to subject.
+// The subscription is created immediately, so the messages published after NewReceiver returns are received;
+// if it fails, Receive returns the error.
+// With the WithQueueGroup option the receivers in the same queue group share the messages.
+func NewReceiver(conn *nats.Conn, subject string, options ...ReceiverOptionFunc) transport.Receiver {
+	r := &receiver{conn: conn, subject: subject}
+	for _, o := range options {
+		o(r)
+	}
+	if r.queueGroup != "" {
+		r.sub, r.err = conn.QueueSubscribeSync(r.subject, r.queueGroup)
+	} else {
+		r.sub, r.err = conn.SubscribeSync(r.subject)
+	}
+	return r
 }
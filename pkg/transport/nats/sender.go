@@ -2,26 +2,72 @@ package nats
 
 import (
 	"context"
+	"time"
 
 	"github.com/cloudevents/sdk-go/pkg/binding"
 	bindings "github.com/cloudevents/sdk-go/pkg/transport"
 	"github.com/nats-io/nats.go"
 )
 
-// sender implements binding.Sender
+// DefaultRequestTimeout is the default time the Requester waits for a response,
+// when the context passed to Request has no deadline
+const DefaultRequestTimeout = 10 * time.Second
+
+// sender implements binding.Sender and binding.Requester
 type sender struct {
-	conn         *nats.Conn
-	subject      string
-	transformers binding.TransformerFactories
+	conn           *nats.Conn
+	subject        string
+	transformers   binding.TransformerFactories
+	requestTimeout time.Duration
+	encoding       Encoding
 }
 
-func (s *sender) Send(ctx context.Context, in binding.Message) error {
-	msg := &nats.Msg{}
-	if err := WriteNATSMessage(ctx, in, msg, nil); err != nil {
+func (s *sender) Send(ctx context.Context, in binding.Message) (err error) {
+	defer func() { _ = in.Finish(err) }()
+	msg, err := s.writeMessage(ctx, in)
+	if err != nil {
+		return err
+	}
+	if err = s.conn.PublishMsg(msg); err != nil {
+		err = bindings.NewUndelivered(TransportName, err)
 		return err
 	}
-	msg.Subject = s.subject // TODO: allow for overwriting this.
-	return s.conn.PublishMsg(msg)
+	return nil
+}
+
+// Request publishes the message to the subject and waits for the response,
+// until ctx is done or, if ctx has no deadline, for the request timeout.
+func (s *sender) Request(ctx context.Context, in binding.Message) (resp binding.Message, err error) {
+	defer func() { _ = in.Finish(err) }()
+	msg, err := s.writeMessage(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := ctx.Deadline(); !ok && s.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.requestTimeout)
+		defer cancel()
+	}
+	reply, err := s.conn.RequestMsgWithContext(ctx, msg)
+	if err != nil {
+		err = bindings.NewUndelivered(TransportName, err)
+		return nil, err
+	}
+	return NewMessage(reply), nil
+}
+
+// writeMessage encodes in in the encoding of the sender, structured unless it's Binary.
+func (s *sender) writeMessage(ctx context.Context, in binding.Message) (*nats.Msg, error) {
+	if s.encoding == Binary {
+		ctx = binding.WithForceBinary(ctx)
+	} else {
+		ctx = binding.WithForceStructured(ctx)
+	}
+	msg := &nats.Msg{Subject: s.subject} // TODO: allow for overwriting this.
+	if err := WriteNATSMessage(ctx, in, msg, s.transformers); err != nil {
+		return nil, err
+	}
+	return msg, nil
 }
 
 func (s *sender) Close(ctx context.Context) error {
@@ -31,7 +77,18 @@ func (s *sender) Close(ctx context.Context) error {
 
 // Create a new nats Sender, implements binding.Sender
 func NewSender(conn *nats.Conn, subject string, options ...SenderOptionFunc) bindings.Sender {
-	s := &sender{conn: conn, subject: subject, transformers: make(binding.TransformerFactories, 0)}
+	return NewRequester(conn, subject, options...)
+}
+
+// Create a new nats Requester, implements binding.Requester.
+// The responses are expected on the reply subject of the requests, like the nats.Conn.Request responses.
+func NewRequester(conn *nats.Conn, subject string, options ...SenderOptionFunc) bindings.Requester {
+	s := &sender{
+		conn:           conn,
+		subject:        subject,
+		transformers:   make(binding.TransformerFactories, 0),
+		requestTimeout: DefaultRequestTimeout,
+	}
 	for _, o := range options {
 		o(s)
 	}
@@ -0,0 +1,139 @@
+package nats
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	natsd "github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/transport"
+)
+
+func testServer(t *testing.T) (string, func()) {
+	srv, err := natsd.NewServer(&natsd.Options{Port: -1})
+	require.NoError(t, err)
+	go srv.Start()
+	require.True(t, srv.ReadyForConnections(10*time.Second), "nats server did not start")
+	return srv.ClientURL(), srv.Shutdown
+}
+
+func testConn(t *testing.T, url string) *nats.Conn {
+	conn, err := nats.Connect(url)
+	require.NoError(t, err)
+	return conn
+}
+
+func TestRequest(t *testing.T) {
+	url, shutdown := testServer(t)
+	defer shutdown()
+
+	for _, encoding := range []Encoding{Default, Binary, Structured} {
+		t.Run(encoding.String(), func(t *testing.T) {
+			r := NewReceiver(testConn(t, url), "requests")
+			defer r.(*receiver).Close(context.Background())
+
+			eventIn := test.FullEvent()
+			eventOut := test.FullEvent()
+			eventOut.SetID("response")
+			received := make(chan binding.Encoding, 1)
+			go func() {
+				m, err := r.Receive(context.Background())
+				if err != nil {
+					close(received)
+					return
+				}
+				received <- m.ReadEncoding()
+				m.(binding.ResponseMessage).Response(context.Background(), binding.EventMessage(eventOut))
+				_ = m.Finish(nil)
+			}()
+
+			s := NewRequester(testConn(t, url), "requests", WithSenderEncoding(encoding))
+			resp, err := s.Request(context.Background(), binding.EventMessage(eventIn))
+			require.NoError(t, err)
+
+			wantEncoding := binding.EncodingStructured
+			if encoding == Binary {
+				wantEncoding = binding.EncodingBinary
+			}
+			require.Equal(t, wantEncoding, <-received)
+			require.Equal(t, wantEncoding, resp.ReadEncoding())
+			got, err := binding.ToEvent(context.Background(), resp, nil)
+			require.NoError(t, err)
+			test.AssertEventEquals(t, test.ExToStr(t, eventOut), test.ExToStr(t, *got))
+		})
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	url, shutdown := testServer(t)
+	defer shutdown()
+
+	// Nobody replies
+	r := NewReceiver(testConn(t, url), "requests")
+	defer r.(*receiver).Close(context.Background())
+	go func() {
+		m, err := r.Receive(context.Background())
+		if err == nil {
+			_ = m.Finish(nil)
+		}
+	}()
+
+	s := NewRequester(testConn(t, url), "requests", WithRequestTimeout(10*time.Millisecond))
+	_, err := s.Request(context.Background(), binding.EventMessage(test.MinEvent()))
+	require.True(t, transport.IsUndelivered(err))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = NewRequester(testConn(t, url), "requests", WithRequestTimeout(0)).Request(ctx, binding.EventMessage(test.MinEvent()))
+	require.True(t, transport.IsUndelivered(err))
+}
+
+func TestQueueGroup(t *testing.T) {
+	url, shutdown := testServer(t)
+	defer shutdown()
+
+	const count = 20
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	received := make(chan string, 2*count)
+	for _, name := range []string{"a", "b"} {
+		r := NewReceiver(testConn(t, url), "events", WithQueueGroup("group"))
+		defer r.(*receiver).Close(context.Background())
+
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for {
+				m, err := r.Receive(ctx)
+				if err != nil {
+					return
+				}
+				received <- name
+				_ = m.Finish(nil)
+			}
+		}(name)
+	}
+
+	s := NewSender(testConn(t, url), "events")
+	for i := 0; i < count; i++ {
+		require.NoError(t, s.Send(context.Background(), binding.EventMessage(test.MinEvent())))
+	}
+
+	// Each message is received by a single receiver of the group
+	for i := 0; i < count; i++ {
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d messages, want %d", i, count)
+		}
+	}
+	cancel()
+	wg.Wait()
+	require.Empty(t, received)
+}
@@ -1,6 +1,8 @@
 package nats
 
 import (
+	"context"
+
 	"github.com/cloudevents/sdk-go/pkg/transport/bindings"
 
 	"github.com/cloudevents/sdk-go/pkg/transport"
@@ -19,11 +21,13 @@ const (
 type Transport struct {
 	bindings.BindingTransport
 
-	Encoding    Encoding
-	Conn        *nats.Conn
-	ConnOptions []nats.Option
-	NatsURL     string
-	Subject     string
+	Encoding        Encoding
+	Conn            *nats.Conn
+	ConnOptions     []nats.Option
+	SenderOptions   []SenderOptionFunc
+	ReceiverOptions []ReceiverOptionFunc
+	NatsURL         string
+	Subject         string
 }
 
 // New creates a new NATS transport.
@@ -44,12 +48,20 @@ func New(natsURL, subject string, opts ...Option) (*Transport, error) {
 		return nil, err
 	}
 
-	t.Sender = NewSender(t.Conn, t.Subject) // TODO: Allow sender options to be passed in.
-	t.Receiver = NewReceiver(t.Conn, t.Subject)
+	senderOptions := append([]SenderOptionFunc{WithSenderEncoding(t.Encoding)}, t.SenderOptions...)
+	t.Requester = NewRequester(t.Conn, t.Subject, senderOptions...)
+	t.Sender = t.Requester
 
 	return t, nil
 }
 
+// StartReceiver subscribes to the subject and implements Transport.StartReceiver
+// NOTE: This is a blocking call.
+func (t *Transport) StartReceiver(ctx context.Context) error {
+	t.Receiver = NewReceiver(t.Conn, t.Subject, t.ReceiverOptions...)
+	return t.BindingTransport.StartReceiver(ctx)
+}
+
 func (t *Transport) connect() error {
 	var err error
 
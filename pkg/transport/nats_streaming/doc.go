@@ -0,0 +1,16 @@
+/*
+Package nats_streaming implements a durable binding using NATS Streaming, with at-least-once delivery.
+
+The Sender publishes the events in structured encoding, waiting for the publish acks of the cluster.
+The Receiver acks the messages when they are finished without error; the messages finished with an
+error are not acked, so the cluster redelivers them after the ack wait.
+
+	conn, err := stan.Connect(clusterID, clientID)
+	defer conn.Close() // The Sender and the Receiver don't close the connection
+	receiver := nats_streaming.NewReceiver(conn, "events",
+		nats_streaming.WithDurableName("consumer"),
+		nats_streaming.WithDeliverAll(),
+		nats_streaming.WithAckWait(10*time.Second),
+	)
+*/
+package nats_streaming
@@ -0,0 +1,48 @@
+package nats_streaming
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/nats-io/stan.go"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+)
+
+// Message implements binding.Message by wrapping a *stan.Msg.
+// This message *can* be read several times safely
+type Message struct {
+	Msg *stan.Msg
+
+	ack func() error
+}
+
+// Wrap a *stan.Msg in a binding.Message, which acks the message when finished without error.
+// The returned message *can* be read several times safely
+func NewMessage(msg *stan.Msg) *Message {
+	return &Message{Msg: msg, ack: msg.Ack}
+}
+
+var _ binding.Message = (*Message)(nil)
+
+func (m *Message) ReadEncoding() binding.Encoding {
+	return binding.EncodingStructured
+}
+
+func (m *Message) ReadStructured(ctx context.Context, encoder binding.StructuredWriter) error {
+	return encoder.SetStructuredEvent(ctx, format.JSON, bytes.NewReader(m.Msg.Data))
+}
+
+func (m *Message) ReadBinary(ctx context.Context, encoder binding.BinaryWriter) error {
+	return binding.ErrNotBinary
+}
+
+// Finish acks the message if err is nil. Otherwise the message is not acked,
+// and it's redelivered after the ack wait of the subscription.
+func (m *Message) Finish(err error) error {
+	if err != nil {
+		return nil
+	}
+	return m.ack()
+}
@@ -0,0 +1,79 @@
+package nats_streaming
+
+import (
+	"time"
+
+	"github.com/nats-io/stan.go"
+	"github.com/nats-io/stan.go/pb"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+)
+
+// nats_streaming.Sender options
+type SenderOptionFunc func(sender *sender)
+
+// Add a transformer, which Sender uses while encoding a binding.Message to the data of a NATS Streaming message
+func WithTransformer(transformer binding.TransformerFactory) SenderOptionFunc {
+	return func(sender *sender) {
+		sender.transformers = append(sender.transformers, transformer)
+	}
+}
+
+// nats_streaming.Receiver options
+type ReceiverOptionFunc func(receiver *receiver)
+
+// Create a durable subscription with name, the cluster keeps its position after the Receiver is closed
+func WithDurableName(name string) ReceiverOptionFunc {
+	return func(receiver *receiver) {
+		receiver.subOptions = append(receiver.subOptions, stan.DurableName(name))
+	}
+}
+
+// Subscribe in the queue group, the messages are shared between the receivers of the same queue group
+func WithQueueGroup(queueGroup string) ReceiverOptionFunc {
+	return func(receiver *receiver) {
+		receiver.queueGroup = queueGroup
+	}
+}
+
+// Receive only the messages published after the subscription is created. This is the default start position
+func WithDeliverNew() ReceiverOptionFunc {
+	return func(receiver *receiver) {
+		receiver.subOptions = append(receiver.subOptions, stan.StartAt(pb.StartPosition_NewOnly))
+	}
+}
+
+// Receive all the messages available in the channel
+func WithDeliverAll() ReceiverOptionFunc {
+	return func(receiver *receiver) {
+		receiver.subOptions = append(receiver.subOptions, stan.DeliverAllAvailable())
+	}
+}
+
+// Receive the messages starting from the sequence number seq
+func WithStartAtSequence(seq uint64) ReceiverOptionFunc {
+	return func(receiver *receiver) {
+		receiver.subOptions = append(receiver.subOptions, stan.StartAtSequence(seq))
+	}
+}
+
+// Receive the messages published starting from t
+func WithStartAtTime(t time.Time) ReceiverOptionFunc {
+	return func(receiver *receiver) {
+		receiver.subOptions = append(receiver.subOptions, stan.StartAtTime(t))
+	}
+}
+
+// Set the time the cluster waits for the ack of a message before redelivering it
+func WithAckWait(ackWait time.Duration) ReceiverOptionFunc {
+	return func(receiver *receiver) {
+		receiver.subOptions = append(receiver.subOptions, stan.AckWait(ackWait))
+	}
+}
+
+// Set the maximum number of messages delivered and not acked yet
+func WithMaxInflight(max int) ReceiverOptionFunc {
+	return func(receiver *receiver) {
+		receiver.subOptions = append(receiver.subOptions, stan.MaxInflight(max))
+	}
+}
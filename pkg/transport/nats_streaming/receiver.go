@@ -0,0 +1,98 @@
+package nats_streaming
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/nats-io/stan.go"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/transport"
+)
+
+// receiver for NATS Streaming, implements transport.Receiver
+type receiver struct {
+	conn    stan.Conn
+	subject string
+
+	queueGroup string
+	subOptions []stan.SubscriptionOption
+
+	// ack acks the message, replaced in tests
+	ack func(*stan.Msg) error
+
+	mu       sync.Mutex
+	sub      stan.Subscription
+	err      error // subscription error
+	incoming chan *stan.Msg
+	closed   chan struct{}
+}
+
+// Receive blocks until the next message is received, or ctx is done.
+// Returns io.EOF if ctx is done, or the receiver is closed.
+func (r *receiver) Receive(ctx context.Context) (binding.Message, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	select {
+	case msg := <-r.incoming:
+		return &Message{Msg: msg, ack: func() error { return r.ack(msg) }}, nil
+	case <-ctx.Done():
+		return nil, io.EOF
+	case <-r.closed:
+		return nil, io.EOF
+	}
+}
+
+func (r *receiver) subscribe() (stan.Subscription, error) {
+	options := append([]stan.SubscriptionOption{stan.SetManualAckMode()}, r.subOptions...)
+	if r.queueGroup != "" {
+		return r.conn.QueueSubscribe(r.subject, r.queueGroup, r.handle, options...)
+	}
+	return r.conn.Subscribe(r.subject, r.handle, options...)
+}
+
+// handle passes the message to Receive, blocking until it's received or the receiver is closed.
+func (r *receiver) handle(msg *stan.Msg) {
+	select {
+	case r.incoming <- msg:
+	case <-r.closed:
+	}
+}
+
+// Close closes the subscription, keeping the durable subscriptions in the cluster.
+// The connection is owned by the caller, which closes it.
+func (r *receiver) Close(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	select {
+	case <-r.closed:
+		return nil
+	default:
+		close(r.closed)
+	}
+	if r.sub == nil {
+		return nil
+	}
+	return r.sub.Close()
+}
+
+// Create a new Receiver subscribing to subject, implements transport.Receiver.
+// The subscription is created immediately, so the messages published after NewReceiver returns are received;
+// if it fails, Receive returns the error.
+// The subscription is in manual ack mode: the messages are acked when finished without error.
+func NewReceiver(conn stan.Conn, subject string, options ...ReceiverOptionFunc) transport.Receiver {
+	r := &receiver{
+		conn:     conn,
+		subject:  subject,
+		ack:      (*stan.Msg).Ack,
+		incoming: make(chan *stan.Msg),
+		closed:   make(chan struct{}),
+	}
+	for _, o := range options {
+		o(r)
+	}
+	r.sub, r.err = r.subscribe()
+	return r
+}
@@ -0,0 +1,199 @@
+package nats_streaming
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	stand "github.com/nats-io/nats-streaming-server/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/stan.go"
+	"github.com/nats-io/stan.go/pb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+)
+
+// fakeConn records the published messages and the subscription
+type fakeConn struct {
+	stan.Conn
+
+	mu         sync.Mutex
+	published  [][]byte
+	publishErr error
+	queueGroup string
+	handler    stan.MsgHandler
+	options    stan.SubscriptionOptions
+	closed     bool
+}
+
+func (c *fakeConn) Publish(subject string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.publishErr != nil {
+		return c.publishErr
+	}
+	c.published = append(c.published, data)
+	return nil
+}
+
+func (c *fakeConn) Subscribe(subject string, cb stan.MsgHandler, opts ...stan.SubscriptionOption) (stan.Subscription, error) {
+	return c.QueueSubscribe(subject, "", cb, opts...)
+}
+
+func (c *fakeConn) QueueSubscribe(subject, qgroup string, cb stan.MsgHandler, opts ...stan.SubscriptionOption) (stan.Subscription, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queueGroup = qgroup
+	c.handler = cb
+	c.options = stan.DefaultSubscriptionOptions
+	for _, o := range opts {
+		if err := o(&c.options); err != nil {
+			return nil, err
+		}
+	}
+	return &fakeSubscription{}, nil
+}
+
+func (c *fakeConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func (c *fakeConn) NatsConn() *nats.Conn { return nil }
+
+type fakeSubscription struct {
+	stan.Subscription
+}
+
+func (s *fakeSubscription) Close() error { return nil }
+
+// testReceiver returns a subscribed receiver, recording the sequence numbers of the acked messages
+func testReceiver(t *testing.T, conn *fakeConn, options ...ReceiverOptionFunc) (*receiver, chan uint64) {
+	r := NewReceiver(conn, "events", options...).(*receiver)
+	acked := make(chan uint64, 10)
+	r.ack = func(msg *stan.Msg) error {
+		acked <- msg.Sequence
+		return nil
+	}
+	require.NoError(t, r.err)
+	return r, acked
+}
+
+func TestReceiverAck(t *testing.T) {
+	conn := &fakeConn{}
+	r, acked := testReceiver(t, conn)
+	require.True(t, conn.options.ManualAcks)
+
+	data, err := WriteMessage(context.Background(), binding.EventMessage(test.FullEvent()), nil)
+	require.NoError(t, err)
+	go conn.handler(&stan.Msg{MsgProto: pb.MsgProto{Sequence: 1, Data: data}})
+	go conn.handler(&stan.Msg{MsgProto: pb.MsgProto{Sequence: 2, Data: data}})
+
+	for i := 0; i < 2; i++ {
+		m, err := r.Receive(context.Background())
+		require.NoError(t, err)
+		e, err := binding.ToEvent(context.Background(), m, nil)
+		require.NoError(t, err)
+		test.AssertEventEquals(t, test.ExToStr(t, test.FullEvent()), test.ExToStr(t, *e))
+
+		seq := m.(*Message).Msg.Sequence
+		if seq == 1 {
+			require.NoError(t, m.Finish(nil))
+			require.Equal(t, uint64(1), <-acked)
+		} else {
+			// Not acked, redelivered by the cluster
+			require.NoError(t, m.Finish(errors.New("failed")))
+			require.Empty(t, acked)
+		}
+	}
+
+	require.NoError(t, r.Close(context.Background()))
+	require.False(t, conn.closed, "the connection is owned by the caller")
+	_, err = r.Receive(context.Background())
+	require.Equal(t, io.EOF, err)
+}
+
+func TestReceiverOptions(t *testing.T) {
+	start := time.Date(2020, 3, 21, 12, 34, 56, 0, time.UTC)
+	testCases := map[string]struct {
+		options []ReceiverOptionFunc
+		want    stan.SubscriptionOptions
+		group   string
+	}{
+		"default": {
+			want: stan.SubscriptionOptions{MaxInflight: stan.DefaultMaxInflight, AckWait: stan.DefaultAckWait, ManualAcks: true},
+		},
+		"durable deliver all": {
+			options: []ReceiverOptionFunc{WithDurableName("durable"), WithDeliverAll(), WithAckWait(time.Minute), WithMaxInflight(10)},
+			want:    stan.SubscriptionOptions{DurableName: "durable", MaxInflight: 10, AckWait: time.Minute, StartAt: pb.StartPosition_First, ManualAcks: true},
+		},
+		"queue group deliver new": {
+			options: []ReceiverOptionFunc{WithQueueGroup("group"), WithDeliverNew()},
+			want:    stan.SubscriptionOptions{MaxInflight: stan.DefaultMaxInflight, AckWait: stan.DefaultAckWait, StartAt: pb.StartPosition_NewOnly, ManualAcks: true},
+			group:   "group",
+		},
+		"sequence": {
+			options: []ReceiverOptionFunc{WithStartAtSequence(42)},
+			want:    stan.SubscriptionOptions{MaxInflight: stan.DefaultMaxInflight, AckWait: stan.DefaultAckWait, StartAt: pb.StartPosition_SequenceStart, StartSequence: 42, ManualAcks: true},
+		},
+		"time": {
+			options: []ReceiverOptionFunc{WithStartAtTime(start)},
+			want:    stan.SubscriptionOptions{MaxInflight: stan.DefaultMaxInflight, AckWait: stan.DefaultAckWait, StartAt: pb.StartPosition_TimeDeltaStart, StartTime: start, ManualAcks: true},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conn := &fakeConn{}
+			testReceiver(t, conn, tc.options...)
+			require.Equal(t, tc.want, conn.options)
+			require.Equal(t, tc.group, conn.queueGroup)
+		})
+	}
+}
+
+func TestReceiverStreamingServer(t *testing.T) {
+	natsOpts := stand.NewNATSOptions()
+	natsOpts.Port = -1
+	srv, err := stand.RunServerWithOpts(stand.GetDefaultOptions(), natsOpts)
+	require.NoError(t, err)
+	defer srv.Shutdown()
+
+	connect := func(clientID string) stan.Conn {
+		conn, err := stan.Connect(srv.ClusterID(), clientID, stan.NatsURL(srv.ClientURL()))
+		require.NoError(t, err)
+		return conn
+	}
+	receiverConn := connect("receiver")
+	defer receiverConn.Close()
+	senderConn := connect("sender")
+	defer senderConn.Close()
+
+	// The message published right after NewReceiver returns is received, with the default start position
+	r := NewReceiver(receiverConn, "events")
+	s := NewSender(senderConn, "events")
+	require.NoError(t, s.Send(context.Background(), binding.EventMessage(test.FullEvent())))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	m, err := r.Receive(ctx)
+	require.NoError(t, err)
+	e, err := binding.ToEvent(ctx, m, nil)
+	require.NoError(t, err)
+	test.AssertEventEquals(t, test.ExToStr(t, test.FullEvent()), test.ExToStr(t, *e))
+	require.NoError(t, m.Finish(nil))
+
+	// Closing the receiver and the sender keeps the connections open
+	require.NoError(t, r.(*receiver).Close(ctx))
+	require.NoError(t, s.(*sender).Close(ctx))
+	require.Equal(t, nats.CONNECTED, receiverConn.NatsConn().Status())
+	require.Equal(t, nats.CONNECTED, senderConn.NatsConn().Status())
+	_, err = r.Receive(ctx)
+	require.Equal(t, io.EOF, err)
+}
@@ -0,0 +1,50 @@
+package nats_streaming
+
+import (
+	"context"
+
+	"github.com/nats-io/stan.go"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/transport"
+)
+
+const (
+	// TransportName is the name of this transport.
+	TransportName = "NATS Streaming"
+)
+
+// sender implements transport.Sender
+type sender struct {
+	conn         stan.Conn
+	subject      string
+	transformers binding.TransformerFactories
+}
+
+// Send publishes the message, returning when the cluster acks it.
+func (s *sender) Send(ctx context.Context, in binding.Message) (err error) {
+	defer func() { _ = in.Finish(err) }()
+	data, err := WriteMessage(ctx, in, s.transformers)
+	if err != nil {
+		return err
+	}
+	if err = s.conn.Publish(s.subject, data); err != nil {
+		err = transport.NewUndelivered(TransportName, err)
+		return err
+	}
+	return nil
+}
+
+// Close does nothing, the connection is owned by the caller, which closes it.
+func (s *sender) Close(ctx context.Context) error {
+	return nil
+}
+
+// Create a new Sender publishing to subject, implements transport.Sender
+func NewSender(conn stan.Conn, subject string, options ...SenderOptionFunc) transport.Sender {
+	s := &sender{conn: conn, subject: subject, transformers: make(binding.TransformerFactories, 0)}
+	for _, o := range options {
+		o(s)
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+package nats_streaming
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nats-io/stan.go"
+	"github.com/nats-io/stan.go/pb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/transport"
+)
+
+func TestSender(t *testing.T) {
+	conn := &fakeConn{}
+	s := NewSender(conn, "events")
+
+	var finishErr error
+	m := binding.WithFinish(binding.EventMessage(test.FullEvent()), func(err error) { finishErr = err })
+	require.NoError(t, s.Send(context.Background(), m))
+	require.Len(t, conn.published, 1)
+	e, err := binding.ToEvent(context.Background(), &Message{Msg: &stan.Msg{MsgProto: pb.MsgProto{Data: conn.published[0]}}}, nil)
+	require.NoError(t, err)
+	test.AssertEventEquals(t, test.ExToStr(t, test.FullEvent()), test.ExToStr(t, *e))
+
+	conn.publishErr = stan.ErrTimeout
+	err = s.Send(context.Background(), m)
+	require.True(t, transport.IsUndelivered(err))
+	require.True(t, errors.Is(err, stan.ErrTimeout))
+	require.Equal(t, err, finishErr)
+
+	require.NoError(t, s.(*sender).Close(context.Background()))
+	require.False(t, conn.closed, "the connection is owned by the caller")
+}
@@ -0,0 +1,36 @@
+package nats_streaming
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+)
+
+// WriteMessage returns the data of the NATS Streaming message encoding the message m in structured encoding.
+// Using context you can tweak the encoding processing (more details on binding.Write documentation).
+func WriteMessage(ctx context.Context, m binding.Message, transformers binding.TransformerFactories) ([]byte, error) {
+	writer := &dataWriter{}
+
+	_, err := binding.Write(
+		ctx,
+		m,
+		writer,
+		nil,
+		transformers,
+	)
+	return writer.data, err
+}
+
+type dataWriter struct {
+	data []byte
+}
+
+func (w *dataWriter) SetStructuredEvent(ctx context.Context, f format.Format, event io.Reader) (err error) {
+	w.data, err = ioutil.ReadAll(event)
+	return err
+}
+
+var _ binding.StructuredWriter = (*dataWriter)(nil) // Test it conforms to the interface
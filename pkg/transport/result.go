@@ -0,0 +1,115 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ResultKind classifies the outcome of sending a message.
+type ResultKind int
+
+const (
+	// ResultACK means the message was accepted by the receiver's peer.
+	ResultACK ResultKind = iota
+	// ResultNACK means the message was delivered, but the receiver's peer rejected it.
+	ResultNACK
+	// ResultUndelivered means the message could not be delivered to the receiver's peer,
+	// e.g. because of a network failure. Retrying the send could succeed.
+	ResultUndelivered
+)
+
+// String pretty-prints the result kind as a string.
+func (k ResultKind) String() string {
+	switch k {
+	case ResultACK:
+		return "ACK"
+	case ResultNACK:
+		return "NACK"
+	case ResultUndelivered:
+		return "Undelivered"
+	default:
+		return "Unknown"
+	}
+}
+
+// Result is the error returned by Senders and Requesters when the protocol reports
+// a failure while sending a message.
+// A successful send returns a nil error, which is equivalent to an ACK.
+// Use errors.As to inspect the returned error:
+//
+//     var result *transport.Result
+//     if errors.As(err, &result) && result.Kind == transport.ResultNACK {
+//         // Message was rejected by the peer, with status code result.StatusCode
+//     }
+type Result struct {
+	// Kind of the result
+	Kind ResultKind
+
+	// Protocol which produced this result, e.g. "http" or "amqp"
+	Protocol string
+
+	// StatusCode is the protocol specific status code, if any.
+	// For example, the HTTP response status code or the Kafka error code.
+	StatusCode int
+
+	// Body is the response body attached to the result, if any.
+	Body []byte
+
+	// Err is the underlying protocol error, if any.
+	Err error
+}
+
+// NewNACK creates a new Result of kind ResultNACK
+func NewNACK(protocol string, statusCode int, body []byte, err error) *Result {
+	return &Result{Kind: ResultNACK, Protocol: protocol, StatusCode: statusCode, Body: body, Err: err}
+}
+
+// NewUndelivered creates a new Result of kind ResultUndelivered
+func NewUndelivered(protocol string, err error) *Result {
+	return &Result{Kind: ResultUndelivered, Protocol: protocol, Err: err}
+}
+
+// Error implements error.Error
+func (r *Result) Error() string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+	if r.StatusCode != 0 {
+		return fmt.Sprintf("%s %s: status code %d", r.Protocol, r.Kind, r.StatusCode)
+	}
+	return fmt.Sprintf("%s %s", r.Protocol, r.Kind)
+}
+
+// Unwrap returns the underlying protocol error
+func (r *Result) Unwrap() error {
+	return r.Err
+}
+
+// ResultOf returns the kind of the result represented by err:
+// ResultACK if err is nil, the Result.Kind if err is or wraps a Result,
+// ResultUndelivered otherwise.
+func ResultOf(err error) ResultKind {
+	if err == nil {
+		return ResultACK
+	}
+	var result *Result
+	if errors.As(err, &result) {
+		return result.Kind
+	}
+	return ResultUndelivered
+}
+
+// IsACK returns true if err is nil or it's a Result of kind ResultACK
+func IsACK(err error) bool {
+	return ResultOf(err) == ResultACK
+}
+
+// IsNACK returns true if err is or wraps a Result of kind ResultNACK
+func IsNACK(err error) bool {
+	return ResultOf(err) == ResultNACK
+}
+
+// IsUndelivered returns true if err is not nil and it's not an ACK or a NACK
+func IsUndelivered(err error) bool {
+	return ResultOf(err) == ResultUndelivered
+}
@@ -0,0 +1,55 @@
+package transport_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/transport"
+)
+
+func TestResultOf(t *testing.T) {
+	nack := transport.NewNACK("HTTP", 400, []byte("bad request"), errors.New("400 Bad Request"))
+	undelivered := transport.NewUndelivered("HTTP", errors.New("connection refused"))
+
+	testCases := map[string]struct {
+		err  error
+		want transport.ResultKind
+	}{
+		"nil":             {err: nil, want: transport.ResultACK},
+		"ack":             {err: &transport.Result{Kind: transport.ResultACK}, want: transport.ResultACK},
+		"nack":            {err: nack, want: transport.ResultNACK},
+		"wrapped nack":    {err: fmt.Errorf("sending: %w", nack), want: transport.ResultNACK},
+		"undelivered":     {err: undelivered, want: transport.ResultUndelivered},
+		"arbitrary error": {err: errors.New("boom"), want: transport.ResultUndelivered},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			require.Equal(t, tc.want, transport.ResultOf(tc.err))
+			require.Equal(t, tc.want == transport.ResultACK, transport.IsACK(tc.err))
+			require.Equal(t, tc.want == transport.ResultNACK, transport.IsNACK(tc.err))
+			require.Equal(t, tc.want == transport.ResultUndelivered, transport.IsUndelivered(tc.err))
+		})
+	}
+}
+
+func TestResultErrorsAs(t *testing.T) {
+	cause := errors.New("400 Bad Request")
+	err := fmt.Errorf("sending: %w", transport.NewNACK("HTTP", 400, []byte("bad request"), cause))
+
+	var result *transport.Result
+	require.True(t, errors.As(err, &result))
+	require.Equal(t, transport.ResultNACK, result.Kind)
+	require.Equal(t, "HTTP", result.Protocol)
+	require.Equal(t, 400, result.StatusCode)
+	require.Equal(t, []byte("bad request"), result.Body)
+	require.True(t, errors.Is(err, cause))
+}
+
+func TestResultError(t *testing.T) {
+	require.Equal(t, "boom", transport.NewUndelivered("NATS", errors.New("boom")).Error())
+	require.Equal(t, "AMQP NACK", transport.NewNACK("AMQP", 0, nil, nil).Error())
+	require.Equal(t, "Kafka NACK: status code 10", transport.NewNACK("Kafka", 10, nil, nil).Error())
+}
@@ -0,0 +1,12 @@
+/*
+Module websocket implements a WebSocket binding using github.com/gorilla/websocket module.
+
+A Protocol wraps a single duplex WebSocket connection, negotiated with one of the CloudEvents
+WebSocket subprotocols (e.g. cloudevents.json), and it implements transport.Sender,
+transport.Receiver and transport.Requester on top of it.
+Each WebSocket message holds exactly one event in structured mode, encoded with the format
+of the negotiated subprotocol.
+
+Use Dial to open a connection as client and Accept or NewHandler to accept connections as server.
+*/
+package websocket
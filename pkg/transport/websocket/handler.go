@@ -0,0 +1,24 @@
+package websocket
+
+import (
+	"context"
+	nethttp "net/http"
+
+	cecontext "github.com/cloudevents/sdk-go/pkg/context"
+	"go.uber.org/zap"
+)
+
+// NewHandler returns an http.Handler which accepts the WebSocket connections and invokes fn for each of them.
+// The connection is closed when fn returns.
+func NewHandler(fn func(ctx context.Context, p *Protocol), options ...ProtocolOptionFunc) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		ctx := r.Context()
+		p, err := Accept(w, r, options...)
+		if err != nil {
+			cecontext.LoggerFrom(ctx).Warnw("failed to accept the websocket connection", zap.Error(err))
+			return
+		}
+		defer func() { _ = p.Close(ctx) }()
+		fn(ctx, p)
+	})
+}
@@ -0,0 +1,41 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+)
+
+// Message holds a WebSocket message, which contains a structured event.
+// This message *can* be read several times safely
+type Message struct {
+	Payload []byte
+	format  format.Format
+}
+
+// Check if websocket.Message implements binding.Message
+var _ binding.Message = (*Message)(nil)
+
+// NewMessage returns a binding.Message that holds the provided payload, encoded with the provided format.
+// The returned binding.Message *can* be read several times safely
+func NewMessage(payload []byte, f format.Format) *Message {
+	return &Message{Payload: payload, format: f}
+}
+
+func (m *Message) ReadEncoding() binding.Encoding {
+	return binding.EncodingStructured
+}
+
+func (m *Message) ReadStructured(ctx context.Context, encoder binding.StructuredWriter) error {
+	return encoder.SetStructuredEvent(ctx, m.format, bytes.NewReader(m.Payload))
+}
+
+func (m *Message) ReadBinary(ctx context.Context, encoder binding.BinaryWriter) error {
+	return binding.ErrNotBinary
+}
+
+func (m *Message) Finish(error) error {
+	return nil
+}
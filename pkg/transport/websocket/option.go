@@ -0,0 +1,13 @@
+package websocket
+
+import "github.com/cloudevents/sdk-go/pkg/binding"
+
+// websocket.Protocol options
+type ProtocolOptionFunc func(protocol *Protocol)
+
+// Add a transformer, which Protocol uses while encoding a binding.Message to a WebSocket message
+func WithTransformer(transformer binding.TransformerFactory) ProtocolOptionFunc {
+	return func(protocol *Protocol) {
+		protocol.transformers = append(protocol.transformers, transformer)
+	}
+}
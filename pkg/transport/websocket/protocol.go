@@ -0,0 +1,188 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/transport"
+)
+
+const (
+	// TransportName is the name of this transport.
+	TransportName = "WebSocket"
+
+	closeTimeout = time.Second
+)
+
+// Protocol implements transport.Sender, transport.Receiver and transport.Requester
+// on top of a single duplex WebSocket connection.
+// Send can be invoked concurrently with Receive, while Request must not be invoked
+// concurrently with Receive, because the response is the next message read from the connection.
+type Protocol struct {
+	conn         *websocket.Conn
+	subprotocol  subprotocol
+	transformers binding.TransformerFactories
+
+	readMu    sync.Mutex
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+}
+
+var (
+	_ transport.Requester     = (*Protocol)(nil)
+	_ transport.ReceiveCloser = (*Protocol)(nil)
+)
+
+// Dial opens a WebSocket connection to url, negotiating one of the SupportedSubprotocols.
+// header is sent with the opening handshake and it can be nil.
+func Dial(ctx context.Context, url string, header nethttp.Header, options ...ProtocolOptionFunc) (*Protocol, error) {
+	dialer := *websocket.DefaultDialer
+	dialer.Subprotocols = SupportedSubprotocols
+	conn, _, err := dialer.DialContext(ctx, url, header)
+	if err != nil {
+		return nil, err
+	}
+	sp, ok := subprotocols[conn.Subprotocol()]
+	if !ok {
+		closeConn(conn, websocket.CloseProtocolError, "unsupported subprotocol")
+		return nil, fmt.Errorf("server negotiated unsupported subprotocol %q", conn.Subprotocol())
+	}
+	return newProtocol(conn, sp, options...), nil
+}
+
+// Accept upgrades the HTTP server connection to a WebSocket connection, negotiating one of the SupportedSubprotocols.
+// If the client doesn't request any of the supported subprotocols, Accept replies with 400 Bad Request.
+func Accept(w nethttp.ResponseWriter, r *nethttp.Request, options ...ProtocolOptionFunc) (*Protocol, error) {
+	supported := false
+	for _, name := range websocket.Subprotocols(r) {
+		if _, supported = subprotocols[name]; supported {
+			break
+		}
+	}
+	if !supported {
+		nethttp.Error(w, "none of the requested subprotocols is supported", nethttp.StatusBadRequest)
+		return nil, fmt.Errorf("client requested unsupported subprotocols %v", websocket.Subprotocols(r))
+	}
+
+	upgrader := websocket.Upgrader{Subprotocols: SupportedSubprotocols}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newProtocol(conn, subprotocols[conn.Subprotocol()], options...), nil
+}
+
+func newProtocol(conn *websocket.Conn, sp subprotocol, options ...ProtocolOptionFunc) *Protocol {
+	p := &Protocol{
+		conn:         conn,
+		subprotocol:  sp,
+		transformers: make(binding.TransformerFactories, 0),
+	}
+	for _, o := range options {
+		o(p)
+	}
+	return p
+}
+
+// Subprotocol returns the negotiated subprotocol
+func (p *Protocol) Subprotocol() string {
+	return p.conn.Subprotocol()
+}
+
+func (p *Protocol) Send(ctx context.Context, m binding.Message) error {
+	var err error
+	defer func() { _ = m.Finish(err) }()
+
+	var buf bytes.Buffer
+	if err = WriteMessage(ctx, m, &buf, p.subprotocol.format, p.transformers); err != nil {
+		return err
+	}
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	stop := watchContext(ctx, p.conn.SetWriteDeadline)
+	defer stop()
+	if err = p.conn.WriteMessage(p.subprotocol.messageType, buf.Bytes()); err != nil {
+		err = transport.NewUndelivered(TransportName, err)
+	}
+	return err
+}
+
+// Receive reads the next message from the connection.
+// It returns io.EOF when the connection is closed by the peer or when ctx is done.
+func (p *Protocol) Receive(ctx context.Context) (binding.Message, error) {
+	p.readMu.Lock()
+	defer p.readMu.Unlock()
+	return p.receive(ctx)
+}
+
+func (p *Protocol) receive(ctx context.Context) (binding.Message, error) {
+	stop := watchContext(ctx, p.conn.SetReadDeadline)
+	_, payload, err := p.conn.ReadMessage()
+	stop()
+	if err != nil {
+		if ctx.Err() != nil || websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	return NewMessage(payload, p.subprotocol.format), nil
+}
+
+// Request sends the message m and returns the next message read from the connection as response
+func (p *Protocol) Request(ctx context.Context, m binding.Message) (binding.Message, error) {
+	p.readMu.Lock()
+	defer p.readMu.Unlock()
+	if err := p.Send(ctx, m); err != nil {
+		return nil, err
+	}
+	return p.receive(ctx)
+}
+
+// Close sends the close frame to the peer and closes the connection
+func (p *Protocol) Close(ctx context.Context) error {
+	var err error
+	p.closeOnce.Do(func() {
+		p.writeMu.Lock()
+		defer p.writeMu.Unlock()
+		err = closeConn(p.conn, websocket.CloseNormalClosure, "")
+	})
+	return err
+}
+
+func closeConn(conn *websocket.Conn, code int, text string) error {
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(closeTimeout))
+	return conn.Close()
+}
+
+// watchContext expires the connection deadline when ctx is done, unblocking the pending read or write.
+// The returned function must be invoked when the operation completes: it waits for the watcher to exit
+// and clears the deadline, so a ctx cancelled after the operation doesn't break the next ones.
+func watchContext(ctx context.Context, setDeadline func(time.Time) error) func() {
+	if ctx.Done() == nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-ctx.Done():
+			_ = setDeadline(time.Now())
+		case <-done:
+		}
+	}()
+	return func() {
+		close(done)
+		<-exited
+		_ = setDeadline(time.Time{})
+	}
+}
@@ -0,0 +1,141 @@
+package websocket
+
+import (
+	"context"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	. "github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	"github.com/cloudevents/sdk-go/pkg/transport/test"
+)
+
+// testProtocols returns the client and the server side of a WebSocket connection
+func testProtocols(t *testing.T) (client *Protocol, server *Protocol, closeFn func()) {
+	accepted := make(chan *Protocol)
+	done := make(chan struct{})
+	srv := httptest.NewServer(NewHandler(func(ctx context.Context, p *Protocol) {
+		accepted <- p
+		<-done
+	}))
+
+	client, err := Dial(context.Background(), "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	require.Equal(t, JsonSubprotocol, client.Subprotocol())
+	server = <-accepted
+
+	return client, server, func() {
+		require.NoError(t, client.Close(context.Background()))
+		close(done)
+		srv.Close()
+	}
+}
+
+func TestSendReceive(t *testing.T) {
+	client, server, closeFn := testProtocols(t)
+	defer closeFn()
+
+	EachEvent(t, Events(), func(t *testing.T, eventIn event.Event) {
+		eventIn = ExToStr(t, eventIn)
+		messages := []binding.Message{
+			MustCreateMockStructuredMessage(eventIn),
+			MustCreateMockBinaryMessage(eventIn),
+			binding.EventMessage(eventIn),
+		}
+		EachMessage(t, messages, func(t *testing.T, in binding.Message) {
+			assertOut := func(out binding.Message) {
+				require.Equal(t, binding.EncodingStructured, out.ReadEncoding())
+				AssertEventEquals(t, eventIn, ExToStr(t, MustToEvent(t, context.Background(), out)))
+			}
+			test.SendReceive(t, context.Background(), in, client, server, assertOut)
+			test.SendReceive(t, context.Background(), in, server, client, assertOut)
+		})
+	})
+}
+
+func TestRequest(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(func(ctx context.Context, p *Protocol) {
+		// Echo
+		for {
+			m, err := p.Receive(ctx)
+			if err != nil {
+				return
+			}
+			if err := p.Send(ctx, m); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	client, err := Dial(context.Background(), "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, client.Close(context.Background())) }()
+
+	eventIn := ExToStr(t, FullEvent())
+	out, err := client.Request(context.Background(), binding.EventMessage(eventIn))
+	require.NoError(t, err)
+	AssertEventEquals(t, eventIn, ExToStr(t, MustToEvent(t, context.Background(), out)))
+}
+
+func TestAcceptUnsupportedSubprotocol(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(func(ctx context.Context, p *Protocol) {
+		t.Error("connection shouldn't be accepted")
+	}))
+	defer srv.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{"cloudevents.unknown"}}
+	_, resp, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.Error(t, err)
+	require.Equal(t, nethttp.StatusBadRequest, resp.StatusCode)
+}
+
+func TestReceiveEOF(t *testing.T) {
+	client, server, closeFn := testProtocols(t)
+	defer closeFn()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := server.Receive(ctx)
+	require.Equal(t, io.EOF, err)
+
+	require.NoError(t, server.Close(context.Background()))
+	_, err = client.Receive(context.Background())
+	require.Equal(t, io.EOF, err)
+}
+
+func TestSendCancelledAfterReturn(t *testing.T) {
+	client, server, closeFn := testProtocols(t)
+	defer closeFn()
+
+	received := make(chan error)
+	go func() {
+		for {
+			m, err := server.Receive(context.Background())
+			if err != nil {
+				received <- err
+				return
+			}
+			_ = m.Finish(nil)
+		}
+	}()
+
+	// Cancelling the ctx once Send returned mustn't expire the deadline of the next writes
+	eventIn := ExToStr(t, FullEvent())
+	for i := 0; i < 1000; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := client.Send(ctx, binding.EventMessage(eventIn))
+		cancel()
+		require.NoError(t, err, "send %d", i)
+	}
+	require.NoError(t, client.Close(context.Background()))
+	require.Equal(t, io.EOF, <-received)
+}
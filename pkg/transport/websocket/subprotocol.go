@@ -0,0 +1,26 @@
+package websocket
+
+import (
+	"github.com/gorilla/websocket"
+
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+)
+
+const (
+	// JsonSubprotocol is the WebSocket subprotocol carrying events using the JSON format
+	JsonSubprotocol = "cloudevents.json"
+)
+
+// SupportedSubprotocols lists the subprotocols offered by Dial and accepted by Accept,
+// in order of preference
+var SupportedSubprotocols = []string{JsonSubprotocol}
+
+// subprotocol describes how events are framed for a CloudEvents WebSocket subprotocol
+type subprotocol struct {
+	format      format.Format
+	messageType int
+}
+
+var subprotocols = map[string]subprotocol{
+	JsonSubprotocol: {format: format.JSON, messageType: websocket.TextMessage},
+}
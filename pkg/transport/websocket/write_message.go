@@ -0,0 +1,55 @@
+package websocket
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	"github.com/cloudevents/sdk-go/pkg/binding/format"
+	"github.com/cloudevents/sdk-go/pkg/event"
+)
+
+// WriteMessage writes the message m to w in structured mode, using the provided format.
+// A message structured with another format is transcoded to f.
+// Using context you can tweak the encoding processing (more details on binding.Write documentation).
+func WriteMessage(ctx context.Context, m binding.Message, w io.Writer, f format.Format, transformers binding.TransformerFactories) error {
+	writer := &structuredWriter{format: f, writer: w}
+	_, err := binding.Write(
+		binding.UseFormatForEvent(ctx, f),
+		m,
+		writer,
+		nil,
+		transformers,
+	)
+	return err
+}
+
+type structuredWriter struct {
+	format format.Format
+	writer io.Writer
+}
+
+func (w *structuredWriter) SetStructuredEvent(ctx context.Context, f format.Format, ev io.Reader) error {
+	if f.MediaType() == w.format.MediaType() {
+		_, err := io.Copy(w.writer, ev)
+		return err
+	}
+
+	// Transcode to the subprotocol format
+	b, err := ioutil.ReadAll(ev)
+	if err != nil {
+		return err
+	}
+	e := event.Event{}
+	if err := f.Unmarshal(b, &e); err != nil {
+		return err
+	}
+	if b, err = w.format.Marshal(e); err != nil {
+		return err
+	}
+	_, err = w.writer.Write(b)
+	return err
+}
+
+var _ binding.StructuredWriter = (*structuredWriter)(nil) // Test it conforms to the interface
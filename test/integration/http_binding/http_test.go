@@ -89,6 +89,22 @@ func TestSendEventReceiveBinary(t *testing.T) {
 	})
 }
 
+func TestSendBatchReceiveBatch(t *testing.T) {
+	close, s, r := testSenderReceiver(t)
+	defer close()
+	events := Events()
+	in := binding.EventBatchMessage(events)
+	test.SendReceive(t, context.Background(), in, s, r, func(out binding.Message) {
+		require.Equal(t, binding.EncodingBatch, out.ReadEncoding())
+		eventsOut, err := binding.ToEvents(context.Background(), out, nil)
+		require.NoError(t, err)
+		require.Len(t, eventsOut, len(events))
+		for i := range events {
+			AssertEventEquals(t, ExToStr(t, events[i]), ExToStr(t, eventsOut[i]))
+		}
+	})
+}
+
 func testSenderReceiver(t testing.TB, options ...http.SenderOptionFunc) (func(), bindings.Sender, bindings.Receiver) {
 	r := http.NewReceiver() // Parameters? Capacity, sync.
 	srv := httptest.NewServer(r)
@@ -0,0 +1,139 @@
+package mqtt_binding
+
+import (
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/eclipse/paho.golang/packets"
+	"github.com/stretchr/testify/require"
+)
+
+const rejectedTopic = "rejected"
+
+// testBroker is a minimal in-process MQTT v5 broker, good enough to test the binding.
+// It routes the PUBLISH packets to the clients subscribed to the exact same topic, with QoS 0.
+// PUBLISH packets to rejectedTopic are NACKed with the "not authorized" reason code.
+type testBroker struct {
+	listener net.Listener
+
+	mu         sync.Mutex
+	subs       map[*brokerConn]map[string]bool
+	subscribed chan string // if not nil, receives the topics subscribed
+}
+
+type brokerConn struct {
+	net.Conn
+	mu sync.Mutex
+}
+
+func (c *brokerConn) write(p interface {
+	WriteTo(w io.Writer) (int64, error)
+}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = p.WriteTo(c.Conn)
+}
+
+func startTestBroker(t testing.TB) *testBroker {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	b := &testBroker{listener: l, subs: make(map[*brokerConn]map[string]bool)}
+	go b.serve()
+	return b
+}
+
+func (b *testBroker) Addr() string { return b.listener.Addr().String() }
+
+func (b *testBroker) Close() { _ = b.listener.Close() }
+
+// Subscribed returns a channel receiving the topics subscribed from now on
+func (b *testBroker) Subscribed() <-chan string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscribed == nil {
+		b.subscribed = make(chan string, 10)
+	}
+	return b.subscribed
+}
+
+func (b *testBroker) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.handle(&brokerConn{Conn: conn})
+	}
+}
+
+func (b *testBroker) handle(c *brokerConn) {
+	defer func() {
+		b.mu.Lock()
+		delete(b.subs, c)
+		b.mu.Unlock()
+		_ = c.Close()
+	}()
+	for {
+		recv, err := packets.ReadPacket(c)
+		if err != nil {
+			return
+		}
+		switch p := recv.Content.(type) {
+		case *packets.Connect:
+			c.write(&packets.Connack{Properties: &packets.Properties{}})
+		case *packets.Subscribe:
+			reasons := make([]byte, 0, len(p.Subscriptions))
+			b.mu.Lock()
+			if b.subs[c] == nil {
+				b.subs[c] = make(map[string]bool)
+			}
+			for topic := range p.Subscriptions {
+				b.subs[c][topic] = true
+				reasons = append(reasons, 0)
+			}
+			subscribed := b.subscribed
+			b.mu.Unlock()
+			c.write(&packets.Suback{PacketID: p.PacketID, Reasons: reasons, Properties: &packets.Properties{}})
+			if subscribed != nil {
+				for topic := range p.Subscriptions {
+					subscribed <- topic
+				}
+			}
+		case *packets.Unsubscribe:
+			reasons := make([]byte, 0, len(p.Topics))
+			b.mu.Lock()
+			for _, topic := range p.Topics {
+				delete(b.subs[c], topic)
+				reasons = append(reasons, 0)
+			}
+			b.mu.Unlock()
+			c.write(&packets.Unsuback{PacketID: p.PacketID, Reasons: reasons, Properties: &packets.Properties{}})
+		case *packets.Publish:
+			if p.Topic == rejectedTopic {
+				c.write(&packets.Puback{PacketID: p.PacketID, ReasonCode: packets.PubackNotAuthorized, Properties: &packets.Properties{}})
+				continue
+			}
+			b.route(p)
+			if p.QoS == 1 {
+				c.write(&packets.Puback{PacketID: p.PacketID, Properties: &packets.Properties{}})
+			}
+		case *packets.Pingreq:
+			c.write(packets.NewControlPacket(packets.PINGRESP))
+		case *packets.Disconnect:
+			return
+		}
+	}
+}
+
+func (b *testBroker) route(p *packets.Publish) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for c, topics := range b.subs {
+		if topics[p.Topic] {
+			c.write(&packets.Publish{Topic: p.Topic, Payload: p.Payload, Properties: p.Properties})
+		}
+	}
+}
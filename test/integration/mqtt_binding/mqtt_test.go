@@ -0,0 +1,159 @@
+package mqtt_binding
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/packets"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+	. "github.com/cloudevents/sdk-go/pkg/binding/test"
+	"github.com/cloudevents/sdk-go/pkg/event"
+	bindings "github.com/cloudevents/sdk-go/pkg/transport"
+	"github.com/cloudevents/sdk-go/pkg/transport/mqtt"
+	"github.com/cloudevents/sdk-go/pkg/transport/test"
+)
+
+func TestSendStructuredMessageToStructured(t *testing.T) {
+	close, s, r := testSenderReceiver(t)
+	defer close()
+	EachEvent(t, Events(), func(t *testing.T, eventIn event.Event) {
+		eventIn = ExToStr(t, eventIn)
+
+		in := MustCreateMockStructuredMessage(eventIn)
+		test.SendReceive(t, binding.WithPreferredEventEncoding(context.TODO(), binding.EncodingStructured), in, s, r, func(out binding.Message) {
+			eventOut := MustToEvent(t, context.Background(), out)
+			assert.Equal(t, binding.EncodingStructured, out.ReadEncoding())
+			AssertEventEquals(t, eventIn, ExToStr(t, eventOut))
+		})
+	})
+}
+
+func TestSendBinaryMessageToBinary(t *testing.T) {
+	close, s, r := testSenderReceiver(t)
+	defer close()
+	EachEvent(t, Events(), func(t *testing.T, eventIn event.Event) {
+		eventIn = ExToStr(t, eventIn)
+
+		in := MustCreateMockBinaryMessage(eventIn)
+		test.SendReceive(t, binding.WithPreferredEventEncoding(context.TODO(), binding.EncodingBinary), in, s, r, func(out binding.Message) {
+			eventOut := MustToEvent(t, context.Background(), out)
+			assert.Equal(t, binding.EncodingBinary, out.ReadEncoding())
+			AssertEventEquals(t, eventIn, ExToStr(t, eventOut))
+		})
+	})
+}
+
+func TestSendEventToBinaryQoS1(t *testing.T) {
+	close, s, r := testSenderReceiver(t, mqtt.WithQoS(1))
+	defer close()
+	EachEvent(t, Events(), func(t *testing.T, eventIn event.Event) {
+		eventIn = ExToStr(t, eventIn)
+
+		in := binding.EventMessage(eventIn)
+		test.SendReceive(t, context.Background(), in, s, r, func(out binding.Message) {
+			eventOut := MustToEvent(t, context.Background(), out)
+			assert.Equal(t, binding.EncodingBinary, out.ReadEncoding())
+			AssertEventEquals(t, eventIn, ExToStr(t, eventOut))
+		})
+	})
+}
+
+func TestSendRejected(t *testing.T) {
+	broker := startTestBroker(t)
+	defer broker.Close()
+	client := testClient(t, broker)
+	defer func() { _ = client.Disconnect(&paho.Disconnect{}) }()
+
+	s := mqtt.NewSender(client, rejectedTopic, mqtt.WithQoS(1))
+	err := s.Send(context.Background(), binding.EventMessage(MinEvent()))
+	require.True(t, bindings.IsNACK(err))
+	var result *bindings.Result
+	require.True(t, errors.As(err, &result))
+	require.Equal(t, mqtt.TransportName, result.Protocol)
+	require.Equal(t, int(packets.PubackNotAuthorized), result.StatusCode)
+}
+
+func TestTransport(t *testing.T) {
+	broker := startTestBroker(t)
+	defer broker.Close()
+
+	topic := "test-ce-client-" + uuid.New().String()
+	tx, err := mqtt.New(broker.Addr(), topic, mqtt.WithEncoding(mqtt.StructuredV1))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tx.Close()) }()
+
+	rx, err := mqtt.New(broker.Addr(), topic, mqtt.WithEncoding(mqtt.BinaryV1))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, rx.Close()) }()
+
+	got := make(chan event.Event)
+	rx.SetDelivery(deliveryFunc(func(ctx context.Context, e event.Event) { got <- e }))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	subscribed := broker.Subscribed()
+	go func() { _ = rx.StartReceiver(ctx) }()
+
+	// Wait for the receiver to subscribe
+	select {
+	case subscribedTopic := <-subscribed:
+		require.Equal(t, topic, subscribedTopic)
+	case <-time.After(time.Second):
+		t.Fatal("the receiver doesn't subscribe")
+	}
+	eventIn := ExToStr(t, FullEvent())
+	require.NoError(t, tx.Send(ctx, eventIn))
+	AssertEventEquals(t, eventIn, ExToStr(t, <-got))
+}
+
+type deliveryFunc func(ctx context.Context, e event.Event)
+
+func (f deliveryFunc) Delivery(ctx context.Context, e event.Event, _ *event.EventResponse) error {
+	f(ctx, e)
+	return nil
+}
+
+func testClient(t testing.TB, broker *testBroker) *paho.Client {
+	t.Helper()
+	conn, err := net.Dial("tcp", broker.Addr())
+	require.NoError(t, err)
+
+	client := paho.NewClient()
+	client.Conn = conn
+	_, err = client.Connect(context.Background(), &paho.Connect{ClientID: uuid.New().String(), KeepAlive: 30, CleanStart: true})
+	require.NoError(t, err)
+	return client
+}
+
+func testSenderReceiver(t testing.TB, options ...mqtt.SenderOptionFunc) (func(), bindings.Sender, bindings.Receiver) {
+	broker := startTestBroker(t)
+	client := testClient(t, broker)
+
+	topicName := "test-ce-client-" + uuid.New().String()
+	r, err := mqtt.NewReceiver(context.Background(), client, topicName)
+	require.NoError(t, err)
+	s := mqtt.NewSender(client, topicName, options...)
+
+	return func() {
+		err = r.Close(context.TODO())
+		require.NoError(t, err)
+		err = s.Close(context.TODO())
+		require.NoError(t, err)
+		err = client.Disconnect(&paho.Disconnect{})
+		require.NoError(t, err)
+		broker.Close()
+	}, s, r
+}
+
+func BenchmarkSendReceive(b *testing.B) {
+	c, s, r := testSenderReceiver(b)
+	defer c() // Cleanup
+	test.BenchmarkSendReceive(b, s, r)
+}